@@ -0,0 +1,255 @@
+// Package clientgen generates a typed Go client package from an OpenAPI document.
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"{{MODULE_NAME}}/cmd/internal/gotypes"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// Options controls client generation
+type Options struct {
+	PackageName string   // Name of the generated package
+	ExcludeTags []string // Operations with any of these tags are skipped
+}
+
+// Generator emits Go source for a client package
+type Generator struct {
+	doc   *openapi.Document
+	opts  Options
+	types *gotypes.TypeSet
+}
+
+// NewGenerator creates a client generator for doc
+func NewGenerator(doc *openapi.Document, opts Options) *Generator {
+	if opts.PackageName == "" {
+		opts.PackageName = "client"
+	}
+	return &Generator{
+		doc:   doc,
+		opts:  opts,
+		types: gotypes.NewTypeSet(doc, gotypes.Options{OptionalStructPointers: true}),
+	}
+}
+
+// Generate returns the gofmt-ed client source files keyed by file name
+func (g *Generator) Generate() (map[string][]byte, error) {
+	operations, err := g.renderOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{
+		"client.go":     fmt.Sprintf(runtimeSource, g.opts.PackageName),
+		"operations.go": operations,
+		"types.go":      g.renderTypes(),
+	}
+
+	out := make(map[string][]byte, len(files))
+	for name, src := range files {
+		formatted, err := format.Source([]byte(src))
+		if err != nil {
+			return nil, fmt.Errorf("generated %s is not valid Go: %w", name, err)
+		}
+		out[name] = formatted
+	}
+	return out, nil
+}
+
+// renderOperations renders one Client method per operation
+func (g *Generator) renderOperations() (string, error) {
+	var ops []openapi.PathOperation
+	for _, o := range g.doc.SortedOperations() {
+		if !g.excluded(o.Operation) {
+			ops = append(ops, o)
+		}
+	}
+
+	var body bytes.Buffer
+	seen := make(map[string]string)
+	usesURL := false
+	for _, o := range ops {
+		name := gotypes.ExportName(operationID(o))
+		if previous, dup := seen[name]; dup {
+			return "", fmt.Errorf("operations %s and %s %s both map to method %s", previous, o.Method, o.Path, name)
+		}
+		seen[name] = o.Method + " " + o.Path
+		if g.renderOperation(&body, name, o) {
+			usesURL = true
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by cmd/generate-client. DO NOT EDIT.\n\npackage %s\n\n", g.opts.PackageName)
+	switch {
+	case usesURL:
+		buf.WriteString("import (\n\t\"context\"\n\t\"net/url\"\n)\n")
+	case len(ops) > 0:
+		buf.WriteString("import \"context\"\n")
+	}
+	buf.Write(body.Bytes())
+	return buf.String(), nil
+}
+
+// renderOperation renders a single Client method and reports whether it
+// needs net/url for path escaping or query parameters
+func (g *Generator) renderOperation(buf *bytes.Buffer, name string, o openapi.PathOperation) bool {
+	args := []string{"ctx context.Context"}
+	var pathParams, queryParams []openapi.Parameter
+	for _, p := range o.Parameters {
+		switch p.In {
+		case "path":
+			pathParams = append(pathParams, p)
+			args = append(args, gotypes.ParamName(p.Name)+" string")
+		case "query":
+			queryParams = append(queryParams, p)
+		}
+	}
+	if len(queryParams) > 0 {
+		args = append(args, "query url.Values")
+	}
+
+	bodyArg := "nil"
+	if rb := o.Operation.RequestBody; rb != nil {
+		if content, ok := rb.Content["application/json"]; ok && content.Schema != nil {
+			args = append(args, "body "+g.paramType(content.Schema, name+"Request"))
+			bodyArg = "body"
+		}
+	}
+
+	result := g.responseType(o.Operation, name+"Response")
+
+	summary := o.Operation.Summary
+	if summary == "" {
+		summary = "calls " + o.Method + " " + o.Path
+	}
+	fmt.Fprintf(buf, "\n// %s calls %s %s: %s\n", name, o.Method, o.Path, summary)
+	for _, q := range queryParams {
+		fmt.Fprintf(buf, "// Query parameter %q is passed in query.\n", q.Name)
+	}
+
+	queryArg := "nil"
+	if len(queryParams) > 0 {
+		queryArg = "query"
+	}
+	pathExpr := pathExpression(o.Path, pathParams)
+	usesURL := len(queryParams) > 0 || pathExpr != strconv.Quote(o.Path)
+
+	if result == "" {
+		fmt.Fprintf(buf, "func (c *Client) %s(%s) error {\n", name, strings.Join(args, ", "))
+		fmt.Fprintf(buf, "\treturn c.do(ctx, %q, %s, %s, %s, nil)\n}\n", o.Method, pathExpr, queryArg, bodyArg)
+		return usesURL
+	}
+
+	fmt.Fprintf(buf, "func (c *Client) %s(%s) (*%s, error) {\n", name, strings.Join(args, ", "), result)
+	fmt.Fprintf(buf, "\tvar out %s\n", result)
+	fmt.Fprintf(buf, "\tif err := c.do(ctx, %q, %s, %s, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n", o.Method, pathExpr, queryArg, bodyArg)
+	buf.WriteString("\treturn &out, nil\n}\n")
+	return usesURL
+}
+
+// responseType returns the Go type of the lowest documented 2xx JSON response
+func (g *Generator) responseType(op *openapi.Operation, hint string) string {
+	var codes []string
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		content, ok := op.Responses[code].Content["application/json"]
+		if ok && content.Schema != nil {
+			return g.types.GoType(content.Schema, hint)
+		}
+	}
+	return ""
+}
+
+// paramType returns the Go type used for a request body argument
+func (g *Generator) paramType(schema *openapi.Schema, hint string) string {
+	t := g.types.GoType(schema, hint)
+	if strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "interface{}" {
+		return t
+	}
+	return "*" + t
+}
+
+// renderTypes renders every type referenced by operations plus ErrorResponse
+func (g *Generator) renderTypes() string {
+	if g.types.Has("ErrorResponse") {
+		g.types.GoType(&openapi.Schema{Ref: "#/components/schemas/ErrorResponse"}, "ErrorResponse")
+	} else {
+		g.declareStandardError()
+	}
+
+	imports, decls := g.types.Render()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by cmd/generate-client. DO NOT EDIT.\n\npackage %s\n\n", g.opts.PackageName)
+	for _, imp := range imports {
+		fmt.Fprintf(&buf, "import %q\n\n", imp)
+	}
+	buf.WriteString(decls)
+	return buf.String()
+}
+
+// declareStandardError declares ErrorResponse for specs that do not define it
+func (g *Generator) declareStandardError() {
+	g.types.Declare("ErrorResponse", "// ErrorResponse is the standard error body\ntype ErrorResponse struct {\n"+
+		"\tError bool `json:\"error\"`\n\tMessage string `json:\"message\"`\n\tStatus int64 `json:\"status\"`\n}\n")
+}
+
+// excluded reports whether an operation carries an excluded tag
+func (g *Generator) excluded(op *openapi.Operation) bool {
+	for _, tag := range op.Tags {
+		for _, ex := range g.opts.ExcludeTags {
+			if tag == ex {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// operationID returns the documented operationId, or one derived from the method and path
+func operationID(o openapi.PathOperation) string {
+	if o.Operation.OperationID != "" {
+		return o.Operation.OperationID
+	}
+	return strings.ToLower(o.Method) + " " + strings.ReplaceAll(o.Path, "/", " ")
+}
+
+// pathExpression renders a Go expression building the request path
+func pathExpression(template string, params []openapi.Parameter) string {
+	if len(params) == 0 {
+		return strconv.Quote(template)
+	}
+
+	var parts []string
+	literal := ""
+	for _, seg := range strings.SplitAfter(template, "/") {
+		trimmed := strings.TrimSuffix(seg, "/")
+		if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
+			if literal != "" {
+				parts = append(parts, strconv.Quote(literal))
+				literal = ""
+			}
+			parts = append(parts, "url.PathEscape("+gotypes.ParamName(trimmed[1:len(trimmed)-1])+")")
+			literal = strings.TrimPrefix(seg, trimmed)
+			continue
+		}
+		literal += seg
+	}
+	if literal != "" {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + ")
+}
@@ -0,0 +1,62 @@
+package clientgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"{{MODULE_NAME}}/cmd/internal/golden"
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	doc, err := openapi.LoadFile(filepath.Join("testdata", "spec.yaml"))
+	require.NoError(t, err)
+
+	files, err := NewGenerator(doc, Options{PackageName: "fixture", ExcludeTags: []string{"docs"}}).Generate()
+	require.NoError(t, err)
+
+	for _, name := range []string{"operations.go", "types.go"} {
+		t.Run(name, func(t *testing.T) {
+			golden.Assert(t, filepath.Join("testdata", name+".golden"), files[name])
+		})
+	}
+	assert.Contains(t, string(files["client.go"]), "package fixture")
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	doc, err := openapi.LoadFile(filepath.Join("testdata", "spec.yaml"))
+	require.NoError(t, err)
+
+	first, err := NewGenerator(doc, Options{}).Generate()
+	require.NoError(t, err)
+	second, err := NewGenerator(doc, Options{}).Generate()
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestGenerateStandardErrorResponse(t *testing.T) {
+	doc := &openapi.Document{Paths: map[string]openapi.PathItem{}}
+
+	files, err := NewGenerator(doc, Options{}).Generate()
+	require.NoError(t, err)
+	assert.Contains(t, string(files["types.go"]), "type ErrorResponse struct")
+}
+
+func TestGenerateURLImport(t *testing.T) {
+	doc := &openapi.Document{Paths: map[string]openapi.PathItem{
+		"/links": {Get: &openapi.Operation{OperationID: "listLinks", Summary: "Lists every stored url. Sorted by name"}},
+	}}
+	files, err := NewGenerator(doc, Options{}).Generate()
+	require.NoError(t, err)
+	assert.NotContains(t, string(files["operations.go"]), `"net/url"`)
+
+	doc.Paths["/links/{id}"] = openapi.PathItem{Get: &openapi.Operation{
+		OperationID: "getLink",
+		Parameters:  []openapi.Parameter{{Name: "id", In: "path", Required: true}},
+	}}
+	files, err = NewGenerator(doc, Options{}).Generate()
+	require.NoError(t, err)
+	assert.Contains(t, string(files["operations.go"]), `"net/url"`)
+}
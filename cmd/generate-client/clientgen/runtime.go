@@ -0,0 +1,215 @@
+package clientgen
+
+// runtimeSource is the hand-written part of every generated client: the
+// Client type, its options, retries and error decoding. %s is the package name.
+const runtimeSource = `// Code generated by cmd/generate-client. DO NOT EDIT.
+
+package %s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPDoer is the subset of *http.Client used by Client
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client calls the API over HTTP
+type Client struct {
+	baseURL    *url.URL
+	httpClient HTTPDoer
+	maxRetries int
+	backoff    time.Duration
+	headers    http.Header
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(doer HTTPDoer) Option {
+	return func(c *Client) {
+		c.httpClient = doer
+	}
+}
+
+// WithRetries sets how many times idempotent requests are retried after
+// transport errors or 429/502/503/504 responses, and the initial backoff
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithHeader adds a header to every request, e.g. for authentication
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// New creates a client for the API served at baseURL
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %%w", err)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		maxRetries: 2,
+		backoff:    200 * time.Millisecond,
+		headers:    make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// APIError is returned for responses outside the 2xx range
+type APIError struct {
+	StatusCode int
+	Response   ErrorResponse // Decoded standard error body, if the server sent one
+	Body       []byte        // Raw response body
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	message := e.Response.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("api error %%d: %%s", e.StatusCode, message)
+}
+
+// do sends a request, retrying idempotent methods, and decodes a 2xx JSON
+// response into out when out is non-nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request body: %%w", err)
+		}
+	}
+
+	// path arrives escaped; keep it as RawPath so it is not escaped twice
+	u := *c.baseURL
+	u.RawPath = strings.TrimRight(u.EscapedPath(), "/") + path
+	unescaped, err := url.PathUnescape(u.RawPath)
+	if err != nil {
+		return fmt.Errorf("invalid request path %%q: %%w", u.RawPath, err)
+	}
+	u.Path = unescaped
+	u.RawQuery = query.Encode()
+
+	retries := 0
+	if isIdempotent(method) {
+		retries = c.maxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, u.String(), payload)
+		if err == nil && !isRetryableStatus(resp.StatusCode) || attempt >= retries {
+			if err != nil {
+				return err
+			}
+			return decodeResponse(resp, out)
+		}
+
+		wait := c.backoff << attempt
+		if err == nil {
+			if after, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil {
+				wait = time.Duration(after) * time.Second
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send performs a single HTTP request
+func (c *Client) send(ctx context.Context, method, target string, payload []byte) (*http.Response, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// decodeResponse converts non-2xx responses to *APIError and decodes 2xx bodies into out
+func decodeResponse(resp *http.Response, out interface{}) error {
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %%w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: data}
+		_ = json.Unmarshal(data, &apiErr.Response)
+		return apiErr
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response body: %%w", err)
+	}
+	return nil
+}
+
+// isIdempotent reports whether a request may safely be retried
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether a response status indicates a transient failure
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+`
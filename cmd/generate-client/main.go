@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"{{MODULE_NAME}}/cmd/generate-client/clientgen"
+	"{{MODULE_NAME}}/cmd/generate-client/tsgen"
+	"{{MODULE_NAME}}/cmd/generate-openapi/analyzer"
+	"{{MODULE_NAME}}/cmd/internal/flags"
+	"{{MODULE_NAME}}/internal/openapi"
+
+	// Import packages to trigger init() functions that register routes
+	_ "{{MODULE_NAME}}/internal/api/handler"
+)
+
+func main() {
+	var (
+		specFile    = flag.String("spec", "", "OpenAPI file to generate from (default: the route registry)")
+		lang        = flag.String("lang", "go", "Client language: go or typescript")
+		outputDir   = flag.String("output", "", "Output directory (default: pkg/client for go, docs/api/typescript for typescript)")
+		packageName = flag.String("package", "client", "Package name of the generated Go client")
+		excludeTags = flag.String("exclude-tags", "docs", "Comma-separated list of tags whose operations are skipped")
+		verbose     = flag.Bool("verbose", false, "Enable verbose logging")
+	)
+	flag.Parse()
+
+	if *verbose {
+		log.SetFlags(log.LstdFlags | log.Lshortfile)
+	}
+
+	doc, err := loadDocument(*specFile)
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI document: %v", err)
+	}
+
+	var files map[string][]byte
+	switch *lang {
+	case "go":
+		if *outputDir == "" {
+			*outputDir = "pkg/client"
+		}
+		files, err = clientgen.NewGenerator(doc, clientgen.Options{
+			PackageName: *packageName,
+			ExcludeTags: flags.SplitList(*excludeTags),
+		}).Generate()
+	case "typescript", "ts":
+		if *outputDir == "" {
+			*outputDir = "docs/api/typescript"
+		}
+		files, err = tsgen.NewGenerator(doc, tsgen.Options{
+			ExcludeTags: flags.SplitList(*excludeTags),
+		}).Generate()
+	default:
+		log.Fatalf("Unknown -lang %q: expected go or typescript", *lang)
+	}
+	if err != nil {
+		log.Fatalf("Failed to generate client: %v", err)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(*outputDir, name)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		log.Printf("Wrote %s", path)
+	}
+
+	fmt.Printf("Generated %s client in %s\n", *lang, *outputDir)
+}
+
+// loadDocument reads specFile, or builds the spec from the route registry when empty
+func loadDocument(specFile string) (*openapi.Document, error) {
+	if specFile != "" {
+		return openapi.LoadFile(specFile)
+	}
+
+	jsonSpec, err := analyzer.NewGenerator().GenerateJSONSpec()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate spec from route registry: %w", err)
+	}
+	return openapi.ParseJSON([]byte(jsonSpec))
+}
@@ -0,0 +1,80 @@
+package tsgen
+
+// runtimeSource is the shared part of every generated TypeScript client:
+// request options, error decoding and the fetch wrapper used by each module.
+const runtimeSource = `// Code generated by cmd/generate-client. DO NOT EDIT.
+
+import type { ErrorResponse } from "./types";
+
+/** Options shared by every module client. */
+export interface ClientOptions {
+  /** Base URL of the API, e.g. "https://api.example.com". */
+  baseUrl: string;
+  /** Custom fetch implementation; defaults to the global fetch. */
+  fetch?: typeof fetch;
+  /** Headers sent with every request, e.g. for authentication. */
+  headers?: Record<string, string>;
+}
+
+/** Thrown for responses outside the 2xx range. */
+export class ApiError extends Error {
+  constructor(
+    public readonly status: number,
+    public readonly response: ErrorResponse | undefined,
+    public readonly body: string,
+  ) {
+    super(` + "`api error ${status}: ${response?.message ?? body}`" + `);
+    this.name = "ApiError";
+  }
+}
+
+export type QueryValue = string | number | boolean | undefined | null;
+
+/** Sends a JSON request and decodes a JSON response. */
+export async function request<T>(
+  options: ClientOptions,
+  method: string,
+  path: string,
+  query?: Record<string, QueryValue>,
+  body?: unknown,
+): Promise<T> {
+  let url = options.baseUrl.replace(/\/+$/, "") + path;
+  if (query) {
+    const params = new URLSearchParams();
+    for (const key of Object.keys(query).sort()) {
+      const value = query[key];
+      if (value !== undefined && value !== null) {
+        params.set(key, String(value));
+      }
+    }
+    const encoded = params.toString();
+    if (encoded) {
+      url += "?" + encoded;
+    }
+  }
+
+  const headers: Record<string, string> = { Accept: "application/json", ...options.headers };
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+  }
+
+  const doFetch = options.fetch ?? fetch;
+  const response = await doFetch(url, {
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+
+  const text = await response.text();
+  if (!response.ok) {
+    let decoded: ErrorResponse | undefined;
+    try {
+      decoded = JSON.parse(text) as ErrorResponse;
+    } catch {
+      decoded = undefined;
+    }
+    throw new ApiError(response.status, decoded, text);
+  }
+  return (text ? JSON.parse(text) : undefined) as T;
+}
+`
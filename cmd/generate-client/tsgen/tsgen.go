@@ -0,0 +1,400 @@
+// Package tsgen generates TypeScript types and a fetch-based client per module
+// from an OpenAPI document.
+package tsgen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// Options controls TypeScript generation
+type Options struct {
+	ExcludeTags []string // Operations with any of these tags are skipped
+}
+
+// Generator emits TypeScript source files
+type Generator struct {
+	doc  *openapi.Document
+	opts Options
+}
+
+// NewGenerator creates a TypeScript generator for doc
+func NewGenerator(doc *openapi.Document, opts Options) *Generator {
+	return &Generator{doc: doc, opts: opts}
+}
+
+// Generate returns the TypeScript files keyed by file name: types.ts, client.ts,
+// one <module>.ts per tag and an index.ts re-exporting them
+func (g *Generator) Generate() (map[string][]byte, error) {
+	modules := g.groupOperations()
+
+	files := map[string][]byte{
+		"types.ts":  []byte(g.renderTypes()),
+		"client.ts": []byte(runtimeSource),
+	}
+
+	index := bytes.NewBufferString(header)
+	index.WriteString("export * from \"./types\";\nexport * from \"./client\";\n")
+
+	for _, module := range sortedKeys(modules) {
+		file := moduleFileName(module)
+		if file == "types" || file == "client" || file == "index" {
+			return nil, fmt.Errorf("module %q maps to reserved file %s.ts", module, file)
+		}
+		source, err := g.renderModule(module, modules[module])
+		if err != nil {
+			return nil, err
+		}
+		files[file+".ts"] = []byte(source)
+		fmt.Fprintf(index, "export * from \"./%s\";\n", file)
+	}
+	files["index.ts"] = index.Bytes()
+	return files, nil
+}
+
+const header = "// Code generated by cmd/generate-client. DO NOT EDIT.\n\n"
+
+// groupOperations groups operations by their first tag
+func (g *Generator) groupOperations() map[string][]openapi.PathOperation {
+	var ops []openapi.PathOperation
+	for _, o := range g.doc.SortedOperations() {
+		if !g.excluded(o.Operation) {
+			ops = append(ops, o)
+		}
+	}
+	return openapi.GroupByModule(ops)
+}
+
+// renderTypes renders one declaration per component schema
+func (g *Generator) renderTypes() string {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+
+	schemas := g.doc.Components.Schemas
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if schemas["ErrorResponse"] == nil {
+		buf.WriteString("/** Standard error body. */\nexport interface ErrorResponse {\n  error: boolean;\n  message: string;\n  status: number;\n}\n\n")
+	}
+
+	for i, name := range names {
+		schema := schemas[name]
+		if schema.Description != "" {
+			fmt.Fprintf(&buf, "/** %s */\n", schema.Description)
+		}
+		if schema.Type == "object" && len(schema.Properties) > 0 {
+			fmt.Fprintf(&buf, "export interface %s %s\n", typeName(name), g.objectType(schema, ""))
+		} else {
+			fmt.Fprintf(&buf, "export type %s = %s;\n", typeName(name), g.tsType(schema, ""))
+		}
+		if i < len(names)-1 {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String()
+}
+
+// tsType returns the TypeScript type for a schema
+func (g *Generator) tsType(schema *openapi.Schema, indent string) string {
+	if schema == nil {
+		return "unknown"
+	}
+
+	var t string
+	switch {
+	case schema.Ref != "":
+		t = typeName(strings.TrimPrefix(schema.Ref, "#/components/schemas/"))
+	case len(schema.Enum) > 0:
+		literals := make([]string, len(schema.Enum))
+		for i, v := range schema.Enum {
+			literals[i] = enumLiteral(v)
+		}
+		t = strings.Join(literals, " | ")
+	case schema.Type == "string":
+		t = "string"
+	case schema.Type == "integer", schema.Type == "number":
+		t = "number"
+	case schema.Type == "boolean":
+		t = "boolean"
+	case schema.Type == "array":
+		item := g.tsType(schema.Items, indent)
+		if strings.ContainsAny(item, "|&") {
+			item = "(" + item + ")"
+		}
+		t = item + "[]"
+	case schema.Type == "object" && len(schema.Properties) > 0:
+		t = g.objectType(schema, indent)
+	case schema.Type == "object":
+		t = "Record<string, unknown>"
+	default:
+		t = "unknown"
+	}
+
+	if schema.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+// objectType renders an inline object type with one property per line
+func (g *Generator) objectType(schema *openapi.Schema, indent string) string {
+	required := make(map[string]bool)
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+
+	props := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		props = append(props, name)
+	}
+	sort.Strings(props)
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for _, prop := range props {
+		propSchema := schema.Properties[prop]
+		if propSchema.Description != "" {
+			fmt.Fprintf(&buf, "%s  /** %s */\n", indent, propSchema.Description)
+		}
+		optional := ""
+		if !required[prop] {
+			optional = "?"
+		}
+		fmt.Fprintf(&buf, "%s  %s%s: %s;\n", indent, propertyName(prop), optional, g.tsType(propSchema, indent+"  "))
+	}
+	buf.WriteString(indent + "}")
+	return buf.String()
+}
+
+// renderModule renders a client class for one module
+func (g *Generator) renderModule(module string, ops []openapi.PathOperation) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+
+	var methods bytes.Buffer
+	seen := make(map[string]string)
+	for _, o := range ops {
+		name := methodName(o)
+		if previous, dup := seen[name]; dup {
+			return "", fmt.Errorf("operations %s and %s %s both map to method %s", previous, o.Method, o.Path, name)
+		}
+		seen[name] = o.Method + " " + o.Path
+		g.renderOperation(&methods, name, o)
+	}
+
+	imports := g.referencedTypes(methods.String())
+	buf.WriteString("import { request } from \"./client\";\nimport type { ClientOptions } from \"./client\";\n")
+	if len(imports) > 0 {
+		fmt.Fprintf(&buf, "import type { %s } from \"./types\";\n", strings.Join(imports, ", "))
+	}
+
+	className := typeName(module) + "Client"
+	fmt.Fprintf(&buf, "\n/** Client for the %s module. */\nexport class %s {\n  constructor(private readonly options: ClientOptions) {}\n", module, className)
+	buf.Write(methods.Bytes())
+	buf.WriteString("}\n")
+	return buf.String(), nil
+}
+
+// renderOperation renders a single client method
+func (g *Generator) renderOperation(buf *bytes.Buffer, name string, o openapi.PathOperation) {
+	var args []string
+	var pathParams, queryParams []openapi.Parameter
+	for _, p := range o.Parameters {
+		switch p.In {
+		case "path":
+			pathParams = append(pathParams, p)
+			args = append(args, camelName(p.Name)+": string")
+		case "query":
+			queryParams = append(queryParams, p)
+		}
+	}
+
+	bodyArg := ""
+	if rb := o.Operation.RequestBody; rb != nil {
+		if content, ok := rb.Content["application/json"]; ok && content.Schema != nil {
+			args = append(args, "body: "+g.tsType(content.Schema, "    "))
+			bodyArg = "body"
+		}
+	}
+
+	queryArg := ""
+	if len(queryParams) > 0 {
+		var fields []string
+		allOptional := true
+		for _, q := range queryParams {
+			optional := "?"
+			if q.Required {
+				optional = ""
+				allOptional = false
+			}
+			fields = append(fields, fmt.Sprintf("%s%s: %s", propertyName(q.Name), optional, g.tsType(q.Schema, "")))
+		}
+		optional := ""
+		if allOptional {
+			optional = "?"
+		}
+		args = append(args, fmt.Sprintf("query%s: { %s }", optional, strings.Join(fields, "; ")))
+		queryArg = "query"
+	}
+
+	result := g.responseType(o.Operation)
+
+	summary := o.Operation.Summary
+	if summary == "" {
+		summary = o.Method + " " + o.Path
+	}
+	fmt.Fprintf(buf, "\n  /** %s (%s %s) */\n", summary, o.Method, o.Path)
+	fmt.Fprintf(buf, "  %s(%s): Promise<%s> {\n", name, strings.Join(args, ", "), result)
+
+	callArgs := []string{"this.options", strconv.Quote(o.Method), pathExpression(o.Path, pathParams)}
+	switch {
+	case bodyArg != "":
+		if queryArg == "" {
+			queryArg = "undefined"
+		}
+		callArgs = append(callArgs, queryArg, bodyArg)
+	case queryArg != "":
+		callArgs = append(callArgs, queryArg)
+	}
+	fmt.Fprintf(buf, "    return request<%s>(%s);\n  }\n", result, strings.Join(callArgs, ", "))
+}
+
+// responseType returns the TypeScript type of the lowest documented 2xx JSON response
+func (g *Generator) responseType(op *openapi.Operation) string {
+	var codes []string
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		content, ok := op.Responses[code].Content["application/json"]
+		if ok && content.Schema != nil {
+			return g.tsType(content.Schema, "    ")
+		}
+	}
+	return "void"
+}
+
+// referencedTypes returns the component type names used in source, sorted
+func (g *Generator) referencedTypes(source string) []string {
+	var names []string
+	for name := range g.doc.Components.Schemas {
+		tn := typeName(name)
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(tn) + `\b`).MatchString(source) {
+			names = append(names, tn)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// excluded reports whether an operation carries an excluded tag
+func (g *Generator) excluded(op *openapi.Operation) bool {
+	for _, tag := range op.Tags {
+		for _, ex := range g.opts.ExcludeTags {
+			if tag == ex {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pathExpression renders a TypeScript expression building the request path
+func pathExpression(template string, params []openapi.Parameter) string {
+	if len(params) == 0 {
+		return strconv.Quote(template)
+	}
+	expr := template
+	for _, p := range params {
+		expr = strings.ReplaceAll(expr, "{"+p.Name+"}", "${encodeURIComponent("+camelName(p.Name)+")}")
+	}
+	return "`" + expr + "`"
+}
+
+// enumLiteral renders an enum value as a TypeScript literal type
+func enumLiteral(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return strconv.Quote(value)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// propertyName quotes property names that are not valid identifiers
+func propertyName(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// typeName converts a schema or module name to a PascalCase identifier
+func typeName(name string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" || unicode.IsDigit(rune(out[0])) {
+		out = "T" + out
+	}
+	return out
+}
+
+// camelName converts a parameter or operation name to a camelCase identifier
+func camelName(name string) string {
+	out := typeName(name)
+	return strings.ToLower(out[:1]) + out[1:]
+}
+
+// methodName returns the method name for an operation, derived from its operationId
+func methodName(o openapi.PathOperation) string {
+	if o.Operation.OperationID != "" {
+		return camelName(o.Operation.OperationID)
+	}
+	return camelName(strings.ToLower(o.Method) + " " + o.Path)
+}
+
+// moduleFileName returns the file name, without extension, for a module
+func moduleFileName(module string) string {
+	return strings.ToLower(typeName(module))
+}
+
+// sortedKeys returns the keys of a module map in order
+func sortedKeys(m map[string][]openapi.PathOperation) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+package tsgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"{{MODULE_NAME}}/cmd/internal/golden"
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateFixture(t *testing.T) map[string][]byte {
+	doc, err := openapi.LoadFile(filepath.Join("testdata", "spec.yaml"))
+	require.NoError(t, err)
+
+	files, err := NewGenerator(doc, Options{ExcludeTags: []string{"docs"}}).Generate()
+	require.NoError(t, err)
+	return files
+}
+
+func TestGenerateGolden(t *testing.T) {
+	files := generateFixture(t)
+
+	assert.NotContains(t, files, "docs.ts")
+	for _, name := range []string{"types.ts", "users.ts", "default.ts", "index.ts"} {
+		t.Run(name, func(t *testing.T) {
+			require.Contains(t, files, name)
+			golden.Assert(t, filepath.Join("testdata", name+".golden"), files[name])
+		})
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	assert.Equal(t, generateFixture(t), generateFixture(t))
+}
+
+func TestTSType(t *testing.T) {
+	g := NewGenerator(&openapi.Document{}, Options{})
+
+	tests := []struct {
+		name     string
+		schema   *openapi.Schema
+		expected string
+	}{
+		{"string", &openapi.Schema{Type: "string"}, "string"},
+		{"integer", &openapi.Schema{Type: "integer"}, "number"},
+		{"nullable", &openapi.Schema{Type: "string", Nullable: true}, "string | null"},
+		{"enum", &openapi.Schema{Type: "string", Enum: []interface{}{"a", "b"}}, `"a" | "b"`},
+		{"enum array", &openapi.Schema{Type: "array", Items: &openapi.Schema{Enum: []interface{}{1, 2}}}, "(1 | 2)[]"},
+		{"ref", &openapi.Schema{Ref: "#/components/schemas/HealthResponse"}, "HealthResponse"},
+		{"free-form object", &openapi.Schema{Type: "object"}, "Record<string, unknown>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, g.tsType(tt.schema, ""))
+		})
+	}
+}
+
+func TestReservedModuleName(t *testing.T) {
+	doc := &openapi.Document{Paths: map[string]openapi.PathItem{
+		"/types": {Get: &openapi.Operation{Tags: []string{"types"}, OperationID: "gettypes"}},
+	}}
+
+	_, err := NewGenerator(doc, Options{}).Generate()
+	assert.Error(t, err)
+}
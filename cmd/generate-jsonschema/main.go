@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"{{MODULE_NAME}}/cmd/internal/flags"
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/pkg/schema"
+
+	// Import packages to trigger init() functions that register routes
+	_ "{{MODULE_NAME}}/internal/api/handler"
+)
+
+func main() {
+	var (
+		outputDir      = flag.String("output", "docs/api/jsonschema", "Output directory for the JSON Schema files")
+		baseURL        = flag.String("base-url", "{{API_BASE_URL}}/schemas", "Base URL of the schema $ids")
+		excludeModules = flag.String("exclude-modules", "docs", "Comma-separated list of modules whose types are skipped")
+	)
+	flag.Parse()
+
+	files, err := schema.Export(routeTypes(flags.SplitList(*excludeModules)), *baseURL)
+	if err != nil {
+		log.Fatalf("Failed to generate JSON Schemas: %v", err)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+	// Remove schemas of types that are no longer used
+	existing, err := filepath.Glob(filepath.Join(*outputDir, "*.json"))
+	if err != nil {
+		log.Fatalf("Failed to list %s: %v", *outputDir, err)
+	}
+	for _, path := range existing {
+		if _, keep := files[filepath.Base(path)]; !keep {
+			if err := os.Remove(path); err != nil {
+				log.Fatalf("Failed to remove %s: %v", path, err)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(*outputDir, name)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		log.Printf("Wrote %s", path)
+	}
+
+	fmt.Printf("Generated %d JSON Schemas in %s\n", len(files), *outputDir)
+}
+
+// routeTypes returns the request and response types of the registered public routes
+func routeTypes(excludeModules []string) []reflect.Type {
+	var roots []reflect.Type
+	for _, route := range types.GetRegisteredRoutes() {
+		if route.IsAdmin() || contains(excludeModules, route.Module) {
+			continue
+		}
+		for _, t := range []reflect.Type{route.RequestType, route.ResponseType} {
+			if t != nil {
+				roots = append(roots, t)
+			}
+		}
+	}
+	return roots
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package collection
+
+import (
+	"encoding/json"
+	"strings"
+
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// postmanSchema identifies the Postman collection format version
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// postmanCollection is the subset of the Postman v2.1 format we emit
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanFolder   `json:"item"`
+	Auth     postmanAuth       `json:"auth"`
+	Variable []postmanVariable `json:"variable"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+type postmanFolder struct {
+	Name string        `json:"name"`
+	Item []postmanItem `json:"item"`
+}
+
+type postmanItem struct {
+	Name    string         `json:"name"`
+	Request postmanRequest `json:"request"`
+}
+
+type postmanRequest struct {
+	Method      string          `json:"method"`
+	Header      []postmanHeader `json:"header"`
+	URL         postmanURL      `json:"url"`
+	Body        *postmanBody    `json:"body,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+type postmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []postmanQuery    `json:"query,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanQuery struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type postmanBody struct {
+	Mode    string             `json:"mode"`
+	Raw     string             `json:"raw"`
+	Options postmanBodyOptions `json:"options"`
+}
+
+type postmanBodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanVariable `json:"bearer"`
+}
+
+type postmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// Postman renders doc as a Postman v2.1 collection with one folder per module.
+// Requests use the {{baseUrl}} and {{authToken}} collection variables.
+func Postman(doc *openapi.Document, opts Options) ([]byte, error) {
+	opts = withDefaults(doc, opts)
+
+	collection := postmanCollection{
+		Info: postmanInfo{
+			Name:        opts.Name,
+			Description: doc.Info.Description,
+			Schema:      postmanSchema,
+		},
+		Item: []postmanFolder{},
+		Auth: postmanAuth{
+			Type:   "bearer",
+			Bearer: []postmanVariable{{Key: "token", Value: "{{authToken}}", Type: "string"}},
+		},
+		Variable: []postmanVariable{
+			{Key: "baseUrl", Value: opts.BaseURL, Type: "string"},
+			{Key: "authToken", Value: "", Type: "string"},
+		},
+	}
+
+	for _, m := range collect(doc) {
+		folder := postmanFolder{Name: m.Name}
+		for _, r := range m.Requests {
+			folder.Item = append(folder.Item, postmanItem{Name: r.Name, Request: postmanRequestFor(r)})
+		}
+		collection.Item = append(collection.Item, folder)
+	}
+
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// postmanRequestFor converts a request to Postman's representation
+func postmanRequestFor(r request) postmanRequest {
+	req := postmanRequest{
+		Method: r.Method,
+		Header: []postmanHeader{{Key: "Accept", Value: "application/json"}},
+	}
+	if r.ID != "" {
+		req.Description = "Operation ID: " + r.ID
+	}
+
+	// Postman writes path variables as :name
+	path := make([]string, 0)
+	for _, segment := range pathSegments(r.Path) {
+		if name, ok := templateParam(segment); ok {
+			segment = ":" + name
+		}
+		path = append(path, segment)
+	}
+	if strings.HasSuffix(r.Path, "/") && r.Path != "/" {
+		path = append(path, "")
+	}
+	req.URL = postmanURL{
+		Raw:  "{{baseUrl}}/" + strings.Join(path, "/"),
+		Host: []string{"{{baseUrl}}"},
+		Path: path,
+	}
+	for _, p := range r.PathParams {
+		req.URL.Variable = append(req.URL.Variable, postmanVariable{Key: p.Name, Value: p.Value})
+	}
+	if len(r.Query) > 0 {
+		var pairs []string
+		for _, q := range r.Query {
+			req.URL.Query = append(req.URL.Query, postmanQuery{Key: q.Name, Value: q.Value, Disabled: !q.Required})
+			if q.Required {
+				pairs = append(pairs, q.Name+"="+q.Value)
+			}
+		}
+		if len(pairs) > 0 {
+			req.URL.Raw += "?" + strings.Join(pairs, "&")
+		}
+	}
+
+	if r.Body != "" {
+		req.Header = append(req.Header, postmanHeader{Key: "Content-Type", Value: "application/json"})
+		body := &postmanBody{Mode: "raw", Raw: r.Body}
+		body.Options.Raw.Language = "json"
+		req.Body = body
+	}
+	return req
+}
+
+// withDefaults fills in the collection name and base URL
+func withDefaults(doc *openapi.Document, opts Options) Options {
+	if opts.Name == "" {
+		opts.Name = doc.Info.Title
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = "http://localhost:8080"
+	}
+	return opts
+}
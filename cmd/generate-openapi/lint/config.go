@@ -0,0 +1,139 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how a rule's findings are reported
+type Severity string
+
+// Rule severities
+const (
+	SeverityOff   Severity = "off"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
+// Rule names
+const (
+	RuleOperationSummary    = "operation-summary"
+	RuleOperationIDUnique   = "operation-id-unique"
+	RuleOperationTags       = "operation-tags"
+	RulePathNaming          = "path-naming"
+	RulePropertyNaming      = "property-naming"
+	RulePropertyDescription = "property-description"
+	RuleResponseCoverage    = "response-coverage"
+	RulePaginationShape     = "pagination-shape"
+	RuleNoInlineObjects     = "no-inline-objects"
+)
+
+// Naming styles accepted by the naming rules
+const (
+	KebabCase = "kebab-case"
+	SnakeCase = "snake_case"
+	CamelCase = "camelCase"
+)
+
+// Config selects the severity of each rule and the conventions they enforce
+type Config struct {
+	Rules      map[string]Severity `yaml:"rules"`
+	Naming     NamingConfig        `yaml:"naming"`
+	Pagination PaginationConfig    `yaml:"pagination"`
+}
+
+// NamingConfig sets the naming style of path segments and properties
+type NamingConfig struct {
+	Paths      string `yaml:"paths"`      // Style of literal path segments
+	Properties string `yaml:"properties"` // Style of schema properties and path wildcards
+}
+
+// PaginationConfig pins the shape of paginated responses. When empty, every
+// paginated response must match the first one found.
+type PaginationConfig struct {
+	Items  string   `yaml:"items"`  // Name of the array property holding the page
+	Fields []string `yaml:"fields"` // Pagination fields, e.g. page, page_size, total
+}
+
+// DefaultConfig returns the configuration used when no file is present
+func DefaultConfig() Config {
+	return Config{
+		Rules: map[string]Severity{
+			RuleOperationSummary:    SeverityError,
+			RuleOperationIDUnique:   SeverityError,
+			RuleOperationTags:       SeverityError,
+			RulePathNaming:          SeverityError,
+			RulePropertyNaming:      SeverityError,
+			RulePropertyDescription: SeverityWarn,
+			RuleResponseCoverage:    SeverityError,
+			RulePaginationShape:     SeverityError,
+			RuleNoInlineObjects:     SeverityWarn,
+		},
+		Naming: NamingConfig{Paths: KebabCase, Properties: SnakeCase},
+	}
+}
+
+// LoadConfig reads a YAML config file on top of DefaultConfig. A missing
+// file yields the defaults.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	var file Config
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for rule, severity := range file.Rules {
+		cfg.Rules[rule] = severity
+	}
+	if file.Naming.Paths != "" {
+		cfg.Naming.Paths = file.Naming.Paths
+	}
+	if file.Naming.Properties != "" {
+		cfg.Naming.Properties = file.Naming.Properties
+	}
+	if file.Pagination.Items != "" || len(file.Pagination.Fields) > 0 {
+		cfg.Pagination = file.Pagination
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate rejects unknown rules, severities and naming styles
+func (c Config) Validate() error {
+	known := DefaultConfig().Rules
+	rules := make([]string, 0, len(c.Rules))
+	for rule := range c.Rules {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		if _, ok := known[rule]; !ok {
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+		switch c.Rules[rule] {
+		case SeverityOff, SeverityWarn, SeverityError:
+		default:
+			return fmt.Errorf("rule %s: unknown severity %q (want error, warn or off)", rule, c.Rules[rule])
+		}
+	}
+	for _, style := range []string{c.Naming.Paths, c.Naming.Properties} {
+		if _, ok := namingStyles[style]; !ok {
+			return fmt.Errorf("unknown naming style %q (want %s, %s or %s)", style, KebabCase, SnakeCase, CamelCase)
+		}
+	}
+	return nil
+}
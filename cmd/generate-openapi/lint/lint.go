@@ -0,0 +1,367 @@
+// Package lint checks a generated OpenAPI document against the API's style
+// rules: naming conventions, summaries and tags, response coverage, a
+// consistent pagination shape and named schemas instead of inline objects.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// Finding is a single rule violation
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Location string // Operation ("GET /users") or JSON pointer into the document
+	Message  string
+}
+
+// String formats a finding for the generator's log
+func (f Finding) String() string {
+	return fmt.Sprintf("%-5s %s %s: %s", f.Severity, f.Rule, f.Location, f.Message)
+}
+
+// Errors returns the number of error findings
+func Errors(findings []Finding) int {
+	n := 0
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			n++
+		}
+	}
+	return n
+}
+
+// namingStyles maps each naming style to the pattern a name must match
+var namingStyles = map[string]*regexp.Regexp{
+	KebabCase: regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+	SnakeCase: regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`),
+	CamelCase: regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+}
+
+// paginationFields are property names that mark a response as paginated,
+// compared without case, underscores or hyphens
+var paginationFields = map[string]bool{
+	"page": true, "pagesize": true, "perpage": true, "pagetoken": true, "nextpagetoken": true,
+	"limit": true, "offset": true, "total": true, "totalcount": true,
+	"cursor": true, "nextcursor": true, "hasmore": true,
+}
+
+// linter accumulates findings for one document
+type linter struct {
+	doc      *openapi.Document
+	cfg      Config
+	findings []Finding
+}
+
+// Lint runs every enabled rule over doc and returns the findings sorted by location
+func Lint(doc *openapi.Document, cfg Config) []Finding {
+	l := &linter{doc: doc, cfg: cfg}
+	l.lintOperations()
+	l.lintSchemas()
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		if l.findings[i].Location != l.findings[j].Location {
+			return l.findings[i].Location < l.findings[j].Location
+		}
+		return l.findings[i].Rule < l.findings[j].Rule
+	})
+	return l.findings
+}
+
+// enabled reports whether a rule produces findings
+func (l *linter) enabled(rule string) bool {
+	severity, ok := l.cfg.Rules[rule]
+	return ok && severity != SeverityOff
+}
+
+// report records a finding for an enabled rule
+func (l *linter) report(rule, location, format string, args ...interface{}) {
+	if !l.enabled(rule) {
+		return
+	}
+	l.findings = append(l.findings, Finding{
+		Rule:     rule,
+		Severity: l.cfg.Rules[rule],
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// lintOperations applies the path and operation rules
+func (l *linter) lintOperations() {
+	operationIDs := make(map[string]string)
+	var pagination *paginationShape
+
+	for _, path := range l.doc.SortedPaths() {
+		l.lintPath(path)
+
+		ops := l.doc.Paths[path].Operations()
+		methods := make([]string, 0, len(ops))
+		for method := range ops {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			op := ops[method]
+			location := method + " " + path
+
+			if strings.TrimSpace(op.Summary) == "" {
+				l.report(RuleOperationSummary, location, "operation has no summary")
+			}
+			if len(op.Tags) == 0 || strings.TrimSpace(op.Tags[0]) == "" {
+				l.report(RuleOperationTags, location, "operation has no tag")
+			}
+			if op.OperationID == "" {
+				l.report(RuleOperationIDUnique, location, "operation has no operationId")
+			} else if previous, dup := operationIDs[op.OperationID]; dup {
+				l.report(RuleOperationIDUnique, location, "operationId %q is also used by %s", op.OperationID, previous)
+			} else {
+				operationIDs[op.OperationID] = location
+			}
+
+			l.lintResponses(location, op)
+			l.lintInlineMedia(location, op)
+
+			if shape := l.pagination(op); shape != nil {
+				expected := l.expectedPagination(pagination)
+				if pagination == nil {
+					pagination = &paginationShape{location: location, items: shape.items, fields: shape.fields}
+				}
+				if expected != nil && !shape.equal(expected) {
+					l.report(RulePaginationShape, location, "paginated response has %s; expected %s as in %s", shape, expected, expected.location)
+				}
+			}
+		}
+	}
+}
+
+// lintPath checks literal path segments and wildcard names against the naming styles
+func (l *linter) lintPath(path string) {
+	pathStyle := namingStyles[l.cfg.Naming.Paths]
+	propertyStyle := namingStyles[l.cfg.Naming.Properties]
+
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment == "" || segment == "{$}" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.TrimSuffix(segment[1:len(segment)-1], "...")
+			if propertyStyle != nil && !propertyStyle.MatchString(name) {
+				l.report(RulePathNaming, path, "path parameter %q is not %s", name, l.cfg.Naming.Properties)
+			}
+			continue
+		}
+		// File-like segments such as openapi.json are checked part by part
+		for _, part := range strings.Split(segment, ".") {
+			if pathStyle != nil && !pathStyle.MatchString(part) {
+				l.report(RulePathNaming, path, "path segment %q is not %s", segment, l.cfg.Naming.Paths)
+				break
+			}
+		}
+	}
+}
+
+// lintResponses requires a success, a client error and a server error response
+func (l *linter) lintResponses(location string, op *openapi.Operation) {
+	var success, clientError, serverError bool
+	for code := range op.Responses {
+		switch {
+		case strings.HasPrefix(code, "2"):
+			success = true
+		case strings.HasPrefix(code, "4"):
+			clientError = true
+		case strings.HasPrefix(code, "5"):
+			serverError = true
+		case code == "default":
+			clientError, serverError = true, true
+		}
+	}
+	if !success {
+		l.report(RuleResponseCoverage, location, "no 2xx response is documented")
+	}
+	if !clientError {
+		l.report(RuleResponseCoverage, location, "no 4xx response is documented")
+	}
+	if !serverError {
+		l.report(RuleResponseCoverage, location, "no 5xx response is documented")
+	}
+}
+
+// lintInlineMedia reports request and response bodies declared inline
+func (l *linter) lintInlineMedia(location string, op *openapi.Operation) {
+	if op.RequestBody != nil {
+		for _, mediaType := range sortedMediaTypes(op.RequestBody.Content) {
+			l.walkInline(op.RequestBody.Content[mediaType].Schema, location, "request body")
+		}
+	}
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		content := op.Responses[code].Content
+		for _, mediaType := range sortedMediaTypes(content) {
+			l.walkInline(content[mediaType].Schema, location, code+" response")
+		}
+	}
+}
+
+// walkInline reports the first inline object found in an operation's schema
+func (l *linter) walkInline(schema *openapi.Schema, location, what string) {
+	if schema == nil || schema.Ref != "" {
+		return
+	}
+	if len(schema.Properties) > 0 {
+		l.report(RuleNoInlineObjects, location, "%s declares an inline object; use a named schema", what)
+		return
+	}
+	l.walkInline(schema.Items, location, what)
+}
+
+// lintSchemas applies the property rules to every component schema
+func (l *linter) lintSchemas() {
+	names := make([]string, 0, len(l.doc.Components.Schemas))
+	for name := range l.doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		l.walkSchema(l.doc.Components.Schemas[name], "#/components/schemas/"+name, true)
+	}
+}
+
+// walkSchema checks the properties of a schema and of the schemas it declares inline
+func (l *linter) walkSchema(schema *openapi.Schema, pointer string, root bool) {
+	if schema == nil || schema.Ref != "" {
+		return
+	}
+	if !root && len(schema.Properties) > 0 {
+		l.report(RuleNoInlineObjects, pointer, "inline object; use a named schema")
+	}
+
+	style := namingStyles[l.cfg.Naming.Properties]
+	props := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		props = append(props, name)
+	}
+	sort.Strings(props)
+
+	for _, name := range props {
+		prop := schema.Properties[name]
+		propPointer := pointer + "/properties/" + name
+		if style != nil && !style.MatchString(name) {
+			l.report(RulePropertyNaming, propPointer, "property %q is not %s", name, l.cfg.Naming.Properties)
+		}
+		if prop != nil && prop.Ref == "" && strings.TrimSpace(prop.Description) == "" {
+			l.report(RulePropertyDescription, propPointer, "property %q has no description", name)
+		}
+		l.walkSchema(prop, propPointer, false)
+	}
+
+	l.walkSchema(schema.Items, pointer+"/items", false)
+	for i, sub := range schema.AllOf {
+		l.walkSchema(sub, fmt.Sprintf("%s/allOf/%d", pointer, i), root)
+	}
+	for i, sub := range schema.AnyOf {
+		l.walkSchema(sub, fmt.Sprintf("%s/anyOf/%d", pointer, i), false)
+	}
+	for i, sub := range schema.OneOf {
+		l.walkSchema(sub, fmt.Sprintf("%s/oneOf/%d", pointer, i), false)
+	}
+}
+
+// paginationShape is the item property and pagination fields of a page response
+type paginationShape struct {
+	location string
+	items    string
+	fields   []string
+}
+
+// String describes the shape for findings
+func (p *paginationShape) String() string {
+	return fmt.Sprintf("items %q with fields [%s]", p.items, strings.Join(p.fields, ", "))
+}
+
+// equal compares the items property and the pagination fields
+func (p *paginationShape) equal(other *paginationShape) bool {
+	if p.items != other.items || len(p.fields) != len(other.fields) {
+		return false
+	}
+	for i := range p.fields {
+		if p.fields[i] != other.fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// expectedPagination returns the configured shape, or the first one found
+func (l *linter) expectedPagination(first *paginationShape) *paginationShape {
+	if l.cfg.Pagination.Items != "" || len(l.cfg.Pagination.Fields) > 0 {
+		fields := append([]string(nil), l.cfg.Pagination.Fields...)
+		sort.Strings(fields)
+		return &paginationShape{location: "the lint config", items: l.cfg.Pagination.Items, fields: fields}
+	}
+	return first
+}
+
+// pagination returns the shape of the success response if it is a page: an
+// object with exactly one array property and at least one pagination field
+func (l *linter) pagination(op *openapi.Operation) *paginationShape {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	if len(codes) == 0 {
+		return nil
+	}
+
+	content, ok := op.Responses[codes[0]].Content["application/json"]
+	if !ok || content.Schema == nil {
+		return nil
+	}
+	schema := l.doc.ResolveSchema(content.Schema)
+	if schema == nil {
+		return nil
+	}
+
+	shape := &paginationShape{}
+	arrays := 0
+	for name, prop := range schema.Properties {
+		if resolved := l.doc.ResolveSchema(prop); resolved != nil && resolved.Type == "array" {
+			shape.items = name
+			arrays++
+			continue
+		}
+		normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+		if paginationFields[normalized] {
+			shape.fields = append(shape.fields, name)
+		}
+	}
+	if arrays != 1 || len(shape.fields) == 0 {
+		return nil
+	}
+	sort.Strings(shape.fields)
+	return shape
+}
+
+// sortedMediaTypes returns content types in a stable order
+func sortedMediaTypes(content map[string]openapi.MediaType) []string {
+	types := make([]string, 0, len(content))
+	for mediaType := range content {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,166 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/cmd/generate-openapi/analyzer"
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "{{MODULE_NAME}}/internal/api/handler"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/user_profiles/{userId}": {
+      "get": {
+        "tags": ["users"],
+        "operationId": "getProfile",
+        "responses": {
+          "200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Profile"}}}}
+        }
+      }
+    },
+    "/users": {
+      "get": {
+        "tags": ["users"],
+        "summary": "List users",
+        "operationId": "listUsers",
+        "responses": {
+          "200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/UserPage"}}}},
+          "400": {"description": "bad"},
+          "500": {"description": "error"}
+        }
+      },
+      "post": {
+        "summary": "Create a user",
+        "operationId": "getProfile",
+        "requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"name": {"type": "string"}}}}}},
+        "responses": {"201": {"description": "created"}, "default": {"description": "error"}}
+      }
+    },
+    "/teams": {
+      "get": {
+        "tags": ["teams"],
+        "summary": "List teams",
+        "operationId": "listTeams",
+        "responses": {
+          "200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TeamPage"}}}},
+          "400": {"description": "bad"},
+          "500": {"description": "error"}
+        }
+      }
+    }
+  },
+  "components": {"schemas": {
+    "Profile": {"type": "object", "properties": {
+      "displayName": {"type": "string", "description": "Shown in the UI"},
+      "address": {"type": "object", "description": "Postal address", "properties": {"city": {"type": "string", "description": "City"}}}
+    }},
+    "UserPage": {"type": "object", "properties": {
+      "items": {"type": "array", "description": "Users", "items": {"type": "string"}},
+      "page": {"type": "integer", "description": "Page number"},
+      "total": {"type": "integer", "description": "Total users"}
+    }},
+    "TeamPage": {"type": "object", "properties": {
+      "teams": {"type": "array", "description": "Teams", "items": {"type": "string"}},
+      "next_cursor": {"type": "string"}
+    }}
+  }}
+}`
+
+func lintTestSpec(t *testing.T, cfg Config) []string {
+	doc, err := openapi.ParseJSON([]byte(testSpec))
+	require.NoError(t, err)
+
+	var lines []string
+	for _, f := range Lint(doc, cfg) {
+		lines = append(lines, f.String())
+	}
+	return lines
+}
+
+func TestLint(t *testing.T) {
+	findings := lintTestSpec(t, DefaultConfig())
+
+	assert.Equal(t, []string{
+		"warn  no-inline-objects #/components/schemas/Profile/properties/address: inline object; use a named schema",
+		`error property-naming #/components/schemas/Profile/properties/displayName: property "displayName" is not snake_case`,
+		`warn  property-description #/components/schemas/TeamPage/properties/next_cursor: property "next_cursor" has no description`,
+		`error path-naming /user_profiles/{userId}: path segment "user_profiles" is not kebab-case`,
+		`error path-naming /user_profiles/{userId}: path parameter "userId" is not snake_case`,
+		"error operation-summary GET /user_profiles/{userId}: operation has no summary",
+		"error response-coverage GET /user_profiles/{userId}: no 4xx response is documented",
+		"error response-coverage GET /user_profiles/{userId}: no 5xx response is documented",
+		`error pagination-shape GET /users: paginated response has items "items" with fields [page, total]; expected items "teams" with fields [next_cursor] as in GET /teams`,
+		"warn  no-inline-objects POST /users: request body declares an inline object; use a named schema",
+		`error operation-id-unique POST /users: operationId "getProfile" is also used by GET /user_profiles/{userId}`,
+		"error operation-tags POST /users: operation has no tag",
+	}, findings)
+}
+
+func TestLint_Config(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Rules[RulePathNaming] = SeverityOff
+	cfg.Rules[RuleNoInlineObjects] = SeverityOff
+	cfg.Rules[RulePropertyDescription] = SeverityOff
+	cfg.Naming.Properties = CamelCase
+	cfg.Pagination = PaginationConfig{Items: "items", Fields: []string{"total", "page"}}
+
+	findings := strings.Join(lintTestSpec(t, cfg), "\n")
+	assert.NotContains(t, findings, "path-naming")
+	assert.NotContains(t, findings, "no-inline-objects")
+	assert.NotContains(t, findings, `"displayName" is not`)
+	assert.Contains(t, findings, `"next_cursor" is not camelCase`)
+	assert.Contains(t, findings, `GET /teams: paginated response has items "teams" with fields [next_cursor]; expected items "items" with fields [page, total] as in the lint config`)
+	assert.NotContains(t, findings, "GET /users: paginated")
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := LoadConfig(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), cfg)
+
+	path := filepath.Join(dir, "lint.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rules:\n  operation-summary: warn\nnaming:\n  properties: camelCase\n"), 0644))
+	cfg, err = LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, SeverityWarn, cfg.Rules[RuleOperationSummary])
+	assert.Equal(t, SeverityError, cfg.Rules[RuleOperationTags])
+	assert.Equal(t, CamelCase, cfg.Naming.Properties)
+	assert.Equal(t, KebabCase, cfg.Naming.Paths)
+
+	for name, content := range map[string]string{
+		"unknown rule":     "rules:\n  no-such-rule: error\n",
+		"unknown severity": "rules:\n  operation-summary: fatal\n",
+		"unknown style":    "naming:\n  paths: SCREAMING\n",
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		_, err := LoadConfig(path)
+		assert.Error(t, err, name)
+	}
+}
+
+// TestRegistrySpec keeps the generated spec free of errors under the repo's lint config
+func TestRegistrySpec(t *testing.T) {
+	cfg, err := LoadConfig("../../../configs/openapi-lint.yaml")
+	require.NoError(t, err)
+
+	jsonSpec, err := analyzer.NewGenerator().GenerateJSONSpec()
+	require.NoError(t, err)
+	doc, err := openapi.ParseJSON([]byte(jsonSpec))
+	require.NoError(t, err)
+
+	for _, f := range Lint(doc, cfg) {
+		if f.Severity == SeverityError {
+			t.Error(f)
+		}
+	}
+}
@@ -1,13 +1,26 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"{{MODULE_NAME}}/cmd/generate-openapi/analyzer"
+	"{{MODULE_NAME}}/cmd/generate-openapi/collection"
+	"{{MODULE_NAME}}/cmd/generate-openapi/lint"
+	"{{MODULE_NAME}}/cmd/generate-openapi/reference"
+	"{{MODULE_NAME}}/cmd/generate-openapi/watch"
+	"{{MODULE_NAME}}/cmd/internal/flags"
+	"{{MODULE_NAME}}/internal/apispec"
+	"{{MODULE_NAME}}/internal/openapi"
 	
 	// Import packages to trigger init() functions that register routes
 	_ "{{MODULE_NAME}}/internal/api/handler"
@@ -17,9 +30,25 @@ func main() {
 	var (
 		outputFile = flag.String("output", "docs/api/openapi.yaml", "Output file for OpenAPI specification")
 		verbose    = flag.Bool("verbose", false, "Enable verbose logging")
+		split      = flag.String("split", "", "Also write split specs: comma-separated list of module, version")
+		refDocs    = flag.Bool("reference", false, "Also write a Markdown and HTML API reference next to the spec")
+		collect    = flag.Bool("collections", false, "Also write a Postman collection and a .http file next to the spec")
+		runLint    = flag.Bool("lint", false, "Lint the spec and exit non-zero when a rule reports an error")
+		lintConfig = flag.String("lint-config", "configs/openapi-lint.yaml", "Lint rule configuration file")
+		watchMode  = flag.Bool("watch", false, "Regenerate whenever the route packages change")
+		watchDirs  = flag.String("watch-dirs", strings.Join(analyzer.PackageDirs, ","), "Comma-separated directories watched in -watch mode")
+		interval   = flag.Duration("watch-interval", watch.DefaultInterval, "Polling interval in -watch mode")
+		notifyURL  = flag.String("notify", "", "URL POSTed after the spec changes in -watch mode, e.g. http://localhost:8080/api/docs/reload")
+		version    = flag.String("version", "", "Spec info.version (default: the build version of this binary)")
+		admin      = flag.Bool("include-admin", false, "Also document admin-only routes")
 	)
 	flag.Parse()
 
+	if *watchMode {
+		runWatch(*outputFile, flags.SplitList(*watchDirs), *interval, *notifyURL)
+		return
+	}
+
 	if *verbose {
 		log.SetFlags(log.LstdFlags | log.Lshortfile)
 	}
@@ -29,6 +58,10 @@ func main() {
 
 	// Create analyzer
 	gen := analyzer.NewGenerator()
+	if *version != "" {
+		gen.SetVersion(*version)
+	}
+	gen.SetIncludeAdmin(*admin)
 
 	// Generate the OpenAPI specification
 	spec, err := gen.GenerateSpec()
@@ -37,7 +70,7 @@ func main() {
 	}
 
 	// Write YAML output file
-	if err := os.WriteFile(*outputFile, []byte(spec), 0644); err != nil {
+	if err := writeFile(*outputFile, []byte(spec)); err != nil {
 		log.Fatalf("Failed to write spec to file: %v", err)
 	}
 
@@ -46,17 +79,208 @@ func main() {
 	if err != nil {
 		log.Printf("Warning: Failed to generate JSON spec: %v", err)
 	} else {
-		jsonOutputFile := strings.TrimSuffix(*outputFile, ".yaml") + ".json"
-		if strings.HasSuffix(*outputFile, ".yml") {
-			jsonOutputFile = strings.TrimSuffix(*outputFile, ".yml") + ".json"
-		}
-		if err := os.WriteFile(jsonOutputFile, []byte(jsonSpec), 0644); err != nil {
+		jsonOutputFile := jsonOutputPath(*outputFile)
+		if err := writeFile(jsonOutputFile, []byte(jsonSpec)); err != nil {
 			log.Printf("Warning: Failed to write JSON spec: %v", err)
 		} else {
 			log.Printf("JSON specification generated at %s", jsonOutputFile)
 		}
 	}
 
+	if *runLint {
+		if err := lintSpec(jsonSpec, *lintConfig); err != nil {
+			log.Fatalf("OpenAPI lint failed: %v", err)
+		}
+	}
+
+	if *split != "" {
+		mode, err := apispec.ParseSplitMode(*split)
+		if err != nil {
+			log.Fatalf("Invalid -split value: %v", err)
+		}
+		if err := writeSplitSpecs(gen, mode, filepath.Join(filepath.Dir(*outputFile), "specs")); err != nil {
+			log.Fatalf("Failed to write split specs: %v", err)
+		}
+	}
+
+	if *refDocs {
+		if err := writeReference(jsonSpec, filepath.Dir(*outputFile)); err != nil {
+			log.Fatalf("Failed to write API reference: %v", err)
+		}
+	}
+
+	if *collect {
+		if err := writeCollections(jsonSpec, filepath.Dir(*outputFile)); err != nil {
+			log.Fatalf("Failed to write request collections: %v", err)
+		}
+	}
+
 	log.Printf("OpenAPI specification generated successfully at %s", *outputFile)
 	fmt.Printf("Generated OpenAPI spec with %d routes\n", len(gen.GetDiscoveredRoutes()))
-}
\ No newline at end of file
+}
+
+// writeSplitSpecs writes one YAML and JSON file per split document plus an
+// index.json that the docs handlers use to build the spec selector
+func writeSplitSpecs(gen *analyzer.Generator, mode apispec.SplitMode, dir string) error {
+	docs, err := gen.GenerateSplitSpecs(mode)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for _, doc := range docs {
+		if err := writeFile(filepath.Join(dir, doc.Name+".yaml"), []byte(doc.YAML())); err != nil {
+			return fmt.Errorf("failed to write %s spec: %w", doc.Name, err)
+		}
+		if err := writeFile(filepath.Join(dir, doc.Name+".json"), []byte(doc.JSON())); err != nil {
+			return fmt.Errorf("failed to write %s spec: %w", doc.Name, err)
+		}
+	}
+
+	index, err := json.MarshalIndent(apispec.BuildSpecIndex(docs), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode spec index: %w", err)
+	}
+	if err := writeFile(filepath.Join(dir, "index.json"), index); err != nil {
+		return fmt.Errorf("failed to write spec index: %w", err)
+	}
+
+	log.Printf("Wrote %d split specifications to %s", len(docs), dir)
+	return nil
+}
+
+// writeReference renders the spec into reference/<module>.md files and a
+// self-contained reference.html in dir
+func writeReference(jsonSpec, dir string) error {
+	doc, err := openapi.ParseJSON([]byte(jsonSpec))
+	if err != nil {
+		return err
+	}
+	ref := reference.Build(doc)
+
+	mdDir := filepath.Join(dir, "reference")
+	if err := os.MkdirAll(mdDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", mdDir, err)
+	}
+	pages := ref.Markdown()
+	// Remove pages of modules that no longer exist
+	existing, err := filepath.Glob(filepath.Join(mdDir, "*.md"))
+	if err != nil {
+		return err
+	}
+	for _, file := range existing {
+		if _, keep := pages[filepath.Base(file)]; keep {
+			continue
+		}
+		if err := os.Remove(file); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+	}
+	for name, content := range pages {
+		if err := writeFile(filepath.Join(mdDir, name), content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	page, err := ref.HTML()
+	if err != nil {
+		return fmt.Errorf("failed to render HTML reference: %w", err)
+	}
+	if err := writeFile(filepath.Join(dir, "reference.html"), page); err != nil {
+		return fmt.Errorf("failed to write HTML reference: %w", err)
+	}
+
+	log.Printf("Wrote API reference for %d modules to %s and %s", len(ref.Modules), mdDir, filepath.Join(dir, "reference.html"))
+	return nil
+}
+
+// lintSpec logs every lint finding and fails if any has error severity
+func lintSpec(jsonSpec, configPath string) error {
+	cfg, err := lint.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	doc, err := openapi.ParseJSON([]byte(jsonSpec))
+	if err != nil {
+		return err
+	}
+
+	findings := lint.Lint(doc, cfg)
+	for _, f := range findings {
+		log.Print(f)
+	}
+	if errs := lint.Errors(findings); errs > 0 {
+		return fmt.Errorf("%d errors, %d warnings", errs, len(findings)-errs)
+	}
+
+	log.Printf("OpenAPI lint passed with %d warnings", len(findings))
+	return nil
+}
+
+// writeCollections writes postman_collection.json and api.http in dir
+func writeCollections(jsonSpec, dir string) error {
+	doc, err := openapi.ParseJSON([]byte(jsonSpec))
+	if err != nil {
+		return err
+	}
+
+	postman, err := collection.Postman(doc, collection.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to render Postman collection: %w", err)
+	}
+	if err := writeFile(filepath.Join(dir, "postman_collection.json"), postman); err != nil {
+		return fmt.Errorf("failed to write Postman collection: %w", err)
+	}
+	if err := writeFile(filepath.Join(dir, "api.http"), collection.HTTPFile(doc, collection.Options{})); err != nil {
+		return fmt.Errorf("failed to write .http file: %w", err)
+	}
+
+	log.Printf("Wrote request collections to %s", dir)
+	return nil
+}
+
+// writeFile writes a generated file, leaving it untouched when unchanged
+func writeFile(path string, data []byte) error {
+	_, err := watch.WriteFileIfChanged(path, data, 0644)
+	return err
+}
+
+// jsonOutputPath returns the JSON spec path that accompanies the YAML output
+func jsonOutputPath(yamlPath string) string {
+	if strings.HasSuffix(yamlPath, ".yml") {
+		return strings.TrimSuffix(yamlPath, ".yml") + ".json"
+	}
+	return strings.TrimSuffix(yamlPath, ".yaml") + ".json"
+}
+
+// watchOnlyFlags are not passed on to the generator subprocess
+var watchOnlyFlags = map[string]bool{"watch": true, "watch-dirs": true, "watch-interval": true, "notify": true}
+
+// runWatch regenerates in a subprocess whenever the watched directories
+// change. A subprocess is needed because routes register from init(), so
+// this process only knows the routes it was built with.
+func runWatch(outputFile string, dirs []string, interval time.Duration, notifyURL string) {
+	command := []string{"go", "run", "./cmd/generate-openapi"}
+	flag.Visit(func(f *flag.Flag) {
+		if !watchOnlyFlags[f.Name] {
+			command = append(command, "-"+f.Name+"="+f.Value.String())
+		}
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	w := watch.New(watch.Options{
+		Dirs:      dirs,
+		Interval:  interval,
+		Command:   command,
+		Outputs:   []string{outputFile, jsonOutputPath(outputFile)},
+		NotifyURL: notifyURL,
+	})
+	if err := w.Run(ctx); err != nil {
+		log.Fatalf("Watch failed: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package reference
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+)
+
+// HTML renders the reference as a single page with inline styles and no
+// scripts or external assets, so it can be archived and opened offline
+func (r *Reference) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var htmlTemplate = template.Must(template.New("reference").Funcs(template.FuncMap{
+	"lower": strings.ToLower,
+	"yesNo": yesNo,
+}).Parse(`<!DOCTYPE html>
+<!-- Code generated by cmd/generate-openapi. DO NOT EDIT. -->
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} API reference</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #1f2328; line-height: 1.5; }
+nav { position: fixed; top: 0; bottom: 0; width: 16rem; overflow-y: auto; padding: 1rem; background: #f6f8fa; border-right: 1px solid #d0d7de; box-sizing: border-box; }
+nav ul { list-style: none; padding-left: 0.75rem; margin: 0.25rem 0; }
+nav a { color: #0969da; text-decoration: none; font-size: 0.9rem; }
+main { margin-left: 16rem; padding: 1rem 2rem; max-width: 60rem; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.25rem; margin-top: 2.5rem; }
+h3 { margin-top: 2rem; }
+table { border-collapse: collapse; margin: 0.5rem 0 1rem; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+pre { background: #f6f8fa; padding: 0.75rem; overflow-x: auto; border-radius: 6px; }
+code { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.85rem; }
+.method { display: inline-block; min-width: 4rem; font-weight: 600; }
+.get { color: #1a7f37; } .post { color: #0969da; } .put, .patch { color: #9a6700; } .delete { color: #cf222e; }
+@media print { nav { display: none; } main { margin-left: 0; } }
+</style>
+</head>
+<body>
+<nav>
+<strong>{{.Title}}</strong>{{if .Version}} <small>{{.Version}}</small>{{end}}
+<ul>
+{{- range .Modules}}
+<li><a href="#module-{{.Slug}}">{{.Name}}</a>
+<ul>
+{{- range .Operations}}
+<li><a href="#{{.Anchor}}"><span class="method {{lower .Method}}">{{.Method}}</span> {{.Path}}</a></li>
+{{- end}}
+</ul>
+</li>
+{{- end}}
+</ul>
+</nav>
+<main>
+<h1>{{.Title}}</h1>
+{{if .Version}}<p>Version: <code>{{.Version}}</code></p>{{end}}
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{- range $module := .Modules}}
+<h2 id="module-{{.Slug}}">{{.Name}}</h2>
+{{- range .Operations}}
+<h3 id="{{.Anchor}}"><span class="method {{lower .Method}}">{{.Method}}</span> <code>{{.Path}}</code></h3>
+{{if .Summary}}<p>{{.Summary}}</p>{{end}}
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .OperationID}}<p>Operation ID: <code>{{.OperationID}}</code></p>{{end}}
+{{- if .Parameters}}
+<h4>Parameters</h4>
+<table>
+<tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{- range .Parameters}}
+<tr><td><code>{{.Name}}</code></td><td>{{.In}}</td><td>{{.Type}}</td><td>{{yesNo .Required}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- with .RequestBody}}
+<h4>Request body</h4>
+<p>Type: {{.Type}}</p>
+<pre><code>{{.Example}}</code></pre>
+{{- end}}
+<h4>Responses</h4>
+<table>
+<tr><th>Status</th><th>Description</th><th>Body</th></tr>
+{{- range .Responses}}
+<tr><td>{{.Status}}</td><td>{{.Description}}</td><td>{{with .Body}}{{.Type}}{{end}}</td></tr>
+{{- end}}
+</table>
+{{- range .Responses}}{{if and .Body (eq (slice .Status 0 1) "2")}}
+<p>Example {{.Status}} response:</p>
+<pre><code>{{.Body.Example}}</code></pre>
+{{- break}}{{end}}{{end}}
+{{- end}}
+{{- if .Schemas}}
+<h3 id="module-{{.Slug}}-schemas">Schemas</h3>
+{{- range .Schemas}}
+<h4 id="{{$module.Slug}}-{{.Anchor}}">{{.Name}}</h4>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{- if .Fields}}
+<table>
+<tr><th>Field</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{- range .Fields}}
+<tr><td><code>{{.Name}}</code></td><td>{{.Type}}</td><td>{{yesNo .Required}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>Type: {{.Type}}</p>
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,139 @@
+package reference
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the reference as an index.md plus one <module>.md per module
+func (r *Reference) Markdown() map[string][]byte {
+	files := make(map[string][]byte, len(r.Modules)+1)
+
+	var index strings.Builder
+	index.WriteString(generatedNotice)
+	fmt.Fprintf(&index, "# %s\n\n", r.Title)
+	if r.Version != "" {
+		fmt.Fprintf(&index, "Version: `%s`\n\n", r.Version)
+	}
+	if r.Description != "" {
+		fmt.Fprintf(&index, "%s\n\n", r.Description)
+	}
+	index.WriteString("| Module | Operations |\n| --- | --- |\n")
+	for _, module := range r.Modules {
+		fmt.Fprintf(&index, "| [%s](%s.md) | %d |\n", cell(module.Name), module.Slug, len(module.Operations))
+		files[module.Slug+".md"] = []byte(r.moduleMarkdown(module))
+	}
+	files["index.md"] = []byte(index.String())
+	return files
+}
+
+// generatedNotice marks rendered files as build output
+const generatedNotice = "<!-- Code generated by cmd/generate-openapi. DO NOT EDIT. -->\n\n"
+
+// moduleMarkdown renders a single module page
+func (r *Reference) moduleMarkdown(module Module) string {
+	var b strings.Builder
+	b.WriteString(generatedNotice)
+	fmt.Fprintf(&b, "# %s\n\n[Back to index](index.md)\n\n", module.Name)
+
+	b.WriteString("| Method | Path | Summary |\n| --- | --- | --- |\n")
+	for _, op := range module.Operations {
+		fmt.Fprintf(&b, "| `%s` | [`%s`](#%s) | %s |\n", op.Method, op.Path, op.Anchor, cell(op.Summary))
+	}
+	b.WriteString("\n")
+
+	for _, op := range module.Operations {
+		fmt.Fprintf(&b, "<a id=\"%s\"></a>\n\n## %s %s\n\n", op.Anchor, op.Method, op.Path)
+		if op.Summary != "" {
+			fmt.Fprintf(&b, "%s\n\n", op.Summary)
+		}
+		if op.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", op.Description)
+		}
+		if op.OperationID != "" {
+			fmt.Fprintf(&b, "Operation ID: `%s`\n\n", op.OperationID)
+		}
+
+		if len(op.Parameters) > 0 {
+			b.WriteString("### Parameters\n\n| Name | In | Type | Required | Description |\n| --- | --- | --- | --- | --- |\n")
+			for _, p := range op.Parameters {
+				fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", p.Name, p.In, cell(p.Type), yesNo(p.Required), cell(p.Description))
+			}
+			b.WriteString("\n")
+		}
+
+		if op.RequestBody != nil {
+			fmt.Fprintf(&b, "### Request body\n\nType: %s\n\n", schemaLink(op.RequestBody.Type, module))
+			writeExample(&b, op.RequestBody.Example)
+		}
+
+		b.WriteString("### Responses\n\n| Status | Description | Body |\n| --- | --- | --- |\n")
+		for _, resp := range op.Responses {
+			body := ""
+			if resp.Body != nil {
+				body = schemaLink(resp.Body.Type, module)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", resp.Status, cell(resp.Description), body)
+		}
+		b.WriteString("\n")
+		for _, resp := range op.Responses {
+			if resp.Body != nil && strings.HasPrefix(resp.Status, "2") {
+				fmt.Fprintf(&b, "Example %s response:\n\n", resp.Status)
+				writeExample(&b, resp.Body.Example)
+				break
+			}
+		}
+	}
+
+	if len(module.Schemas) > 0 {
+		b.WriteString("## Schemas\n\n")
+		for _, schema := range module.Schemas {
+			fmt.Fprintf(&b, "<a id=\"%s\"></a>\n\n### %s\n\n", schema.Anchor, schema.Name)
+			if schema.Description != "" {
+				fmt.Fprintf(&b, "%s\n\n", schema.Description)
+			}
+			if len(schema.Fields) == 0 {
+				fmt.Fprintf(&b, "Type: %s\n\n", cell(schema.Type))
+				continue
+			}
+			b.WriteString("| Field | Type | Required | Description |\n| --- | --- | --- | --- |\n")
+			for _, f := range schema.Fields {
+				fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", f.Name, cell(f.Type), yesNo(f.Required), cell(f.Description))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// writeExample writes a fenced JSON example
+func writeExample(b *strings.Builder, example string) {
+	fmt.Fprintf(b, "```json\n%s\n```\n\n", example)
+}
+
+// schemaLink links a type label to its schema section when it names a module schema
+func schemaLink(label string, module Module) string {
+	for _, schema := range module.Schemas {
+		if label == schema.Name {
+			return fmt.Sprintf("[%s](#%s)", cell(label), schema.Anchor)
+		}
+		if label == "array of "+schema.Name {
+			return fmt.Sprintf("array of [%s](#%s)", cell(schema.Name), schema.Anchor)
+		}
+	}
+	return cell(label)
+}
+
+// cell escapes text for use inside a Markdown table cell
+func cell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// yesNo renders a boolean for a table cell
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,339 @@
+// Package reference renders an OpenAPI document into a static API reference:
+// one Markdown file per module and a single self-contained HTML page.
+package reference
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"{{MODULE_NAME}}/internal/mock"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// exampleSeed fixes synthesized examples so output is reproducible
+const exampleSeed = 1
+
+// maxFieldDepth bounds how deeply nested inline objects are flattened
+const maxFieldDepth = 4
+
+// Reference is the renderer-independent model of an API reference
+type Reference struct {
+	Title       string
+	Version     string
+	Description string
+	Modules     []Module
+}
+
+// Module groups the operations sharing a tag with the schemas they use
+type Module struct {
+	Name       string
+	Slug       string
+	Operations []Operation
+	Schemas    []Schema
+}
+
+// Operation documents a single method and path
+type Operation struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	OperationID string
+	Anchor      string
+	Parameters  []Parameter
+	RequestBody *Payload
+	Responses   []Response
+}
+
+// Parameter documents a path, query or header parameter
+type Parameter struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// Payload documents a JSON body with an example
+type Payload struct {
+	Type    string
+	Example string
+}
+
+// Response documents one status code
+type Response struct {
+	Status      string
+	Description string
+	Body        *Payload
+}
+
+// Schema documents a component schema as a flat list of fields
+type Schema struct {
+	Name        string
+	Anchor      string
+	Type        string
+	Description string
+	Fields      []Field
+}
+
+// Field documents one property; nested properties use dotted names
+type Field struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// Build converts doc into a Reference. Modules are named after the first tag
+// of their operations and sorted by name; operations keep path order.
+func Build(doc *openapi.Document) *Reference {
+	ref := &Reference{
+		Title:       doc.Info.Title,
+		Version:     doc.Info.Version,
+		Description: doc.Info.Description,
+	}
+
+	byModule := make(map[string]*Module)
+	used := make(map[string]map[string]bool)
+	for _, o := range doc.SortedOperations() {
+		name := o.Module()
+		module, ok := byModule[name]
+		if !ok {
+			module = &Module{Name: name, Slug: slug(name)}
+			byModule[name] = module
+			used[name] = make(map[string]bool)
+		}
+		module.Operations = append(module.Operations, buildOperation(doc, o, used[name]))
+	}
+
+	names := make([]string, 0, len(byModule))
+	for name := range byModule {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		module := byModule[name]
+		module.Schemas = buildSchemas(doc, used[name])
+		ref.Modules = append(ref.Modules, *module)
+	}
+	return ref
+}
+
+// buildOperation documents one operation, recording the component schemas it uses
+func buildOperation(doc *openapi.Document, o openapi.PathOperation, used map[string]bool) Operation {
+	op := o.Operation
+	out := Operation{
+		Method:      o.Method,
+		Path:        o.Path,
+		Summary:     op.Summary,
+		Description: op.Description,
+		OperationID: op.OperationID,
+		Anchor:      slug(o.Method + " " + o.Path),
+	}
+
+	for _, p := range o.Parameters {
+		out.Parameters = append(out.Parameters, Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Type:        typeLabel(p.Schema),
+			Required:    p.Required,
+			Description: p.Description,
+		})
+	}
+
+	if op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/json"]; ok {
+			out.RequestBody = buildPayload(doc, media, used)
+		}
+	}
+
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		resp := op.Responses[code]
+		r := Response{Status: code, Description: resp.Description}
+		if media, ok := resp.Content["application/json"]; ok {
+			r.Body = buildPayload(doc, media, used)
+		}
+		out.Responses = append(out.Responses, r)
+	}
+	return out
+}
+
+// buildPayload documents a JSON media type. Documented examples are preferred;
+// otherwise an example is synthesized from the schema.
+func buildPayload(doc *openapi.Document, media openapi.MediaType, used map[string]bool) *Payload {
+	if media.Schema == nil {
+		return nil
+	}
+	collectRefs(doc, media.Schema, used)
+
+	example := media.Example
+	if example == nil && len(media.Examples) > 0 {
+		keys := make([]string, 0, len(media.Examples))
+		for k := range media.Examples {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		example = media.Examples[keys[0]].Value
+	}
+	if example == nil {
+		example = mock.NewSynthesizer(doc, exampleSeed).Value(media.Schema)
+	}
+
+	data, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", example))
+	}
+	return &Payload{Type: typeLabel(media.Schema), Example: string(data)}
+}
+
+// collectRefs records every component schema reachable from schema
+func collectRefs(doc *openapi.Document, schema *openapi.Schema, used map[string]bool) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		name := refName(schema.Ref)
+		if used[name] {
+			return
+		}
+		used[name] = true
+		collectRefs(doc, doc.Components.Schemas[name], used)
+		return
+	}
+	collectRefs(doc, schema.Items, used)
+	for _, prop := range schema.Properties {
+		collectRefs(doc, prop, used)
+	}
+	for _, group := range [][]*openapi.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, s := range group {
+			collectRefs(doc, s, used)
+		}
+	}
+}
+
+// buildSchemas documents the named component schemas, sorted by name
+func buildSchemas(doc *openapi.Document, used map[string]bool) []Schema {
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var schemas []Schema
+	for _, name := range names {
+		schema := doc.Components.Schemas[name]
+		if schema == nil {
+			continue
+		}
+		s := Schema{
+			Name:        name,
+			Anchor:      "schema-" + slug(name),
+			Type:        typeLabel(schema),
+			Description: schema.Description,
+			Fields:      flattenFields(schema, "", 0),
+		}
+		schemas = append(schemas, s)
+	}
+	return schemas
+}
+
+// flattenFields lists an object's properties, descending into inline objects
+func flattenFields(schema *openapi.Schema, prefix string, depth int) []Field {
+	if schema == nil || depth > maxFieldDepth {
+		return nil
+	}
+
+	required := make(map[string]bool)
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		props = append(props, name)
+	}
+	sort.Strings(props)
+
+	var fields []Field
+	for _, name := range props {
+		prop := schema.Properties[name]
+		fields = append(fields, Field{
+			Name:        prefix + name,
+			Type:        typeLabel(prop),
+			Required:    required[name],
+			Description: prop.Description,
+		})
+		switch {
+		case prop.Ref == "" && len(prop.Properties) > 0:
+			fields = append(fields, flattenFields(prop, prefix+name+".", depth+1)...)
+		case prop.Ref == "" && prop.Items != nil && prop.Items.Ref == "" && len(prop.Items.Properties) > 0:
+			fields = append(fields, flattenFields(prop.Items, prefix+name+"[].", depth+1)...)
+		}
+	}
+	return fields
+}
+
+// typeLabel describes a schema's type in a few words, e.g. "array of User"
+func typeLabel(schema *openapi.Schema) string {
+	if schema == nil {
+		return "any"
+	}
+
+	var label string
+	switch {
+	case schema.Ref != "":
+		label = refName(schema.Ref)
+	case schema.Type == "array":
+		label = "array of " + typeLabel(schema.Items)
+	case schema.Type == "object" && len(schema.Properties) == 0:
+		label = "map"
+	case schema.Type == "":
+		label = "any"
+	default:
+		label = schema.Type
+		if schema.Format != "" {
+			label += " (" + schema.Format + ")"
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, v := range schema.Enum {
+			values[i] = fmt.Sprint(v)
+		}
+		label += ", one of: " + strings.Join(values, ", ")
+	}
+	if schema.Nullable {
+		label += ", nullable"
+	}
+	return label
+}
+
+// refName returns the component name of a local schema reference
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+// slug converts text to a lowercase anchor or file name
+func slug(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,154 @@
+package reference
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: Reference Test
+  version: 2.1.0
+paths:
+  /users/{id}:
+    get:
+      tags: [users]
+      summary: Get a user | by id
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: User identifier
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        "404":
+          description: Not found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ErrorResponse'
+  /health:
+    get:
+      tags: [health]
+      summary: Health check <b>now</b>
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  status:
+                    type: string
+              example:
+                status: HEALTHY
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          example: u-1
+        role:
+          type: string
+          enum: [admin, member]
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+              description: City name
+    ErrorResponse:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+func buildTestReference(t *testing.T) *Reference {
+	doc, err := openapi.ParseYAML([]byte(testSpec))
+	require.NoError(t, err)
+	return Build(doc)
+}
+
+func TestBuild(t *testing.T) {
+	ref := buildTestReference(t)
+
+	require.Len(t, ref.Modules, 2)
+	assert.Equal(t, "health", ref.Modules[0].Name)
+	assert.Equal(t, "users", ref.Modules[1].Name)
+
+	users := ref.Modules[1]
+	require.Len(t, users.Operations, 1)
+	op := users.Operations[0]
+	assert.Equal(t, []Parameter{{Name: "id", In: "path", Type: "string", Required: true, Description: "User identifier"}}, op.Parameters)
+	require.Len(t, op.Responses, 2)
+	assert.Equal(t, "User", op.Responses[0].Body.Type)
+	assert.Contains(t, op.Responses[0].Body.Example, `"id": "u-1"`)
+
+	require.Len(t, users.Schemas, 2)
+	assert.Equal(t, "ErrorResponse", users.Schemas[0].Name)
+	assert.Equal(t, []Field{
+		{Name: "address", Type: "object"},
+		{Name: "address.city", Type: "string", Description: "City name"},
+		{Name: "id", Type: "string", Required: true},
+		{Name: "role", Type: "string, one of: admin, member"},
+	}, users.Schemas[1].Fields)
+}
+
+func TestBuildUsesDocumentedExample(t *testing.T) {
+	ref := buildTestReference(t)
+
+	health := ref.Modules[0].Operations[0]
+	assert.Equal(t, "{\n  \"status\": \"HEALTHY\"\n}", health.Responses[0].Body.Example)
+}
+
+func TestMarkdown(t *testing.T) {
+	files := buildTestReference(t).Markdown()
+
+	require.Contains(t, files, "index.md")
+	assert.Contains(t, string(files["index.md"]), "| [users](users.md) | 1 |")
+
+	users := string(files["users.md"])
+	assert.Contains(t, users, "| `id` | path | string | yes | User identifier |")
+	assert.Contains(t, users, `Get a user \| by id`, "pipes in cells must be escaped")
+	assert.Contains(t, users, "| 200 | OK | [User](#schema-user) |")
+	assert.Contains(t, users, `<a id="schema-user"></a>`)
+	assert.Contains(t, users, "| `address.city` | string | no | City name |")
+	assert.Contains(t, users, "```json\n{\n  \"address\"")
+}
+
+func TestMarkdownIsDeterministic(t *testing.T) {
+	assert.Equal(t, buildTestReference(t).Markdown(), buildTestReference(t).Markdown())
+}
+
+func TestHTMLIsSelfContained(t *testing.T) {
+	page, err := buildTestReference(t).HTML()
+	require.NoError(t, err)
+	html := string(page)
+
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "<link")
+	assert.False(t, regexp.MustCompile(`(src|href)="(https?:)?//`).MatchString(html), "page must not load external assets")
+
+	assert.Contains(t, html, `<h3 id="get-users-id">`)
+	assert.Contains(t, html, "Health check &lt;b&gt;now&lt;/b&gt;", "text must be escaped")
+	assert.Equal(t, 2, strings.Count(html, "Example 200 response"), "one example per operation with a 2xx body")
+}
@@ -0,0 +1,236 @@
+// Package watch reruns the OpenAPI generator when the sources it documents
+// change. Routes register from init() functions, so every run is a fresh
+// subprocess that rebuilds the generator with the edited packages.
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultInterval is how often the watched directories are polled
+const DefaultInterval = 500 * time.Millisecond
+
+// Options configures a Watcher
+type Options struct {
+	Dirs      []string      // Directories watched recursively for .go changes
+	Interval  time.Duration // Polling interval; DefaultInterval when zero
+	Command   []string      // Generator command run after each change
+	Outputs   []string      // Files compared before and after each run
+	NotifyURL string        // Optional URL POSTed when an output changes
+	Client    *http.Client  // Client used for notifications; http.DefaultClient when nil
+}
+
+// Watcher polls source directories and reruns the generator on change
+type Watcher struct {
+	opts Options
+}
+
+// fileState is the part of a file's metadata that reveals an edit
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// New creates a watcher
+func New(opts Options) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	return &Watcher{opts: opts}
+}
+
+// Run generates once, then regenerates after every change until ctx is done.
+// Failed runs, such as compile errors mid-edit, are logged and the watcher
+// keeps going.
+func (w *Watcher) Run(ctx context.Context) error {
+	previous, err := snapshot(w.opts.Dirs)
+	if err != nil {
+		return err
+	}
+	log.Printf("Watching %s for changes", strings.Join(w.opts.Dirs, ", "))
+	w.rebuild(ctx)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := snapshot(w.opts.Dirs)
+		if err != nil {
+			log.Printf("Warning: failed to scan watched directories: %v", err)
+			continue
+		}
+		if sameSnapshot(previous, current) {
+			continue
+		}
+		// Editors often save several files at once; wait for the tree to settle
+		settled, err := w.settle(ctx, current)
+		if err != nil {
+			return nil
+		}
+		previous = settled
+		w.rebuild(ctx)
+	}
+}
+
+// settle polls until two consecutive snapshots match
+func (w *Watcher) settle(ctx context.Context, current map[string]fileState) (map[string]fileState, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(w.opts.Interval):
+		}
+		next, err := snapshot(w.opts.Dirs)
+		if err != nil {
+			return nil, err
+		}
+		if sameSnapshot(current, next) {
+			return next, nil
+		}
+		current = next
+	}
+}
+
+// rebuild runs the generator once and logs the outcome
+func (w *Watcher) rebuild(ctx context.Context) {
+	changed, err := w.Rebuild(ctx)
+	switch {
+	case err != nil && ctx.Err() == nil:
+		log.Printf("Generation failed: %v", err)
+	case changed:
+		log.Printf("Specification updated")
+	case err == nil:
+		log.Printf("Specification unchanged")
+	}
+}
+
+// Rebuild runs the generator command and reports whether any output changed.
+// A change is POSTed to NotifyURL so a running dev server can reload its docs.
+func (w *Watcher) Rebuild(ctx context.Context) (bool, error) {
+	if len(w.opts.Command) == 0 {
+		return false, fmt.Errorf("no generator command configured")
+	}
+	before := hashFiles(w.opts.Outputs)
+
+	cmd := exec.CommandContext(ctx, w.opts.Command[0], w.opts.Command[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return false, err
+	}
+
+	after := hashFiles(w.opts.Outputs)
+	changed := false
+	for path, sum := range after {
+		if before[path] != sum {
+			changed = true
+		}
+	}
+	if changed && w.opts.NotifyURL != "" {
+		if err := w.notify(ctx); err != nil {
+			log.Printf("Warning: failed to notify %s: %v", w.opts.NotifyURL, err)
+		}
+	}
+	return changed, nil
+}
+
+// notify tells the dev server that the spec changed
+func (w *Watcher) notify(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.NotifyURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := w.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// snapshot records the state of every .go file under dirs. Missing
+// directories are skipped so a watch can start before a package exists.
+func snapshot(dirs []string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil // Removed while walking; the next poll sees it gone
+			}
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// sameSnapshot reports whether no file was added, removed or modified
+func sameSnapshot(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		other, ok := b[path]
+		if !ok || !other.modTime.Equal(state.modTime) || other.size != state.size {
+			return false
+		}
+	}
+	return true
+}
+
+// hashFiles returns a content hash per path; missing files hash to ""
+func hashFiles(paths []string) map[string]string {
+	sums := make(map[string]string, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			sums[path] = ""
+			continue
+		}
+		sums[path] = fmt.Sprintf("%x", sha256.Sum256(data))
+	}
+	return sums
+}
+
+// WriteFileIfChanged writes data unless path already holds it, so unchanged
+// outputs keep their modification time. It reports whether it wrote.
+func WriteFileIfChanged(path string, data []byte, perm os.FileMode) (bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && string(existing) == string(data) {
+		return false, nil
+	}
+	return true, os.WriteFile(path, data, perm)
+}
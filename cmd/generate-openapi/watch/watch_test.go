@@ -0,0 +1,132 @@
+package watch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+	first, err := snapshot([]string{dir, filepath.Join(dir, "missing")})
+	require.NoError(t, err)
+	assert.Len(t, first, 1)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("still ignored"), 0644))
+	second, err := snapshot([]string{dir})
+	require.NoError(t, err)
+	assert.True(t, sameSnapshot(first, second))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.go"), []byte("package sub"), 0644))
+	third, err := snapshot([]string{dir})
+	require.NoError(t, err)
+	assert.False(t, sameSnapshot(second, third))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a // edited"), 0644))
+	fourth, err := snapshot([]string{dir})
+	require.NoError(t, err)
+	assert.False(t, sameSnapshot(third, fourth))
+}
+
+func TestRebuild(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "openapi.json")
+
+	var notified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		atomic.AddInt32(&notified, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	generate := func(content string) *Watcher {
+		return New(Options{
+			Command:   []string{"sh", "-c", "printf '" + content + "' > " + output},
+			Outputs:   []string{output},
+			NotifyURL: server.URL,
+		})
+	}
+
+	changed, err := generate("v1").Rebuild(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&notified))
+
+	changed, err = generate("v1").Rebuild(context.Background())
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&notified))
+
+	changed, err = generate("v2").Rebuild(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&notified))
+
+	_, err = New(Options{Command: []string{"sh", "-c", "exit 3"}}).Rebuild(context.Background())
+	assert.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "routes.go")
+	counter := filepath.Join(dir, "runs")
+	require.NoError(t, os.WriteFile(source, []byte("package routes"), 0644))
+
+	w := New(Options{
+		Dirs:     []string{dir},
+		Interval: 10 * time.Millisecond,
+		Command:  []string{"sh", "-c", "echo run >> " + counter},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	runs := func() int {
+		data, _ := os.ReadFile(counter)
+		return len(data) / len("run\n")
+	}
+	require.Eventually(t, func() bool { return runs() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// Bump the modification time explicitly; some filesystems have coarse timestamps
+	require.NoError(t, os.WriteFile(source, []byte("package routes // edited"), 0644))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(source, later, later))
+	require.Eventually(t, func() bool { return runs() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestWriteFileIfChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+
+	wrote, err := WriteFileIfChanged(path, []byte("a"), 0644)
+	require.NoError(t, err)
+	assert.True(t, wrote)
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+	wrote, err = WriteFileIfChanged(path, []byte("a"), 0644)
+	require.NoError(t, err)
+	assert.False(t, wrote)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old))
+
+	wrote, err = WriteFileIfChanged(path, []byte("b"), 0644)
+	require.NoError(t, err)
+	assert.True(t, wrote)
+}
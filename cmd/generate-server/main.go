@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"{{MODULE_NAME}}/cmd/generate-server/stubgen"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+func main() {
+	var (
+		specFile    = flag.String("spec", "api/openapi.yaml", "OpenAPI document to generate stubs from")
+		outputDir   = flag.String("output", "internal/api/stubs", "Output directory for the generated package")
+		packageName = flag.String("package", "stubs", "Package name of the generated stubs")
+		verbose     = flag.Bool("verbose", false, "Enable verbose logging")
+	)
+	flag.Parse()
+
+	if *verbose {
+		log.SetFlags(log.LstdFlags | log.Lshortfile)
+	}
+
+	doc, err := openapi.LoadFile(*specFile)
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI document: %v", err)
+	}
+
+	files, err := stubgen.NewGenerator(doc, stubgen.Options{PackageName: *packageName}).Generate()
+	if err != nil {
+		log.Fatalf("Failed to generate server stubs: %v", err)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(*outputDir, name)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		log.Printf("Wrote %s", path)
+	}
+
+	fmt.Printf("Generated server stubs for %d paths in %s\n", len(doc.Paths), *outputDir)
+}
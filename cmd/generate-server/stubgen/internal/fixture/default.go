@@ -0,0 +1,40 @@
+// Code generated by cmd/generate-server. DO NOT EDIT.
+
+package fixture
+
+import (
+	"context"
+	"net/http"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+// DefaultHandler implements the default module. Every method receives the decoded
+// path parameters and body; returning a *types.StatusError selects the error status.
+type DefaultHandler interface {
+	// Ping serves GET /api/v1/ping: Liveness probe
+	Ping(ctx context.Context) error
+}
+
+// defaultHandler serves the default module; nil until SetDefaultHandler is called
+var defaultHandler DefaultHandler
+
+// SetDefaultHandler installs the implementation behind the default routes
+func SetDefaultHandler(h DefaultHandler) {
+	defaultHandler = h
+}
+
+// handlePing adapts Ping to net/http
+func handlePing(w http.ResponseWriter, r *http.Request) {
+	impl := defaultHandler
+	if impl == nil {
+		types.WriteError(w, http.StatusNotImplemented, "default handler is not implemented")
+		return
+	}
+
+	if err := impl.Ping(r.Context()); err != nil {
+		types.WriteStatusError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,33 @@
+// Code generated by cmd/generate-server. DO NOT EDIT.
+
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// maxBodyBytes bounds request bodies decoded by the generated adapters
+const maxBodyBytes = 1 << 20
+
+// decodeJSON decodes a JSON request body into v, rejecting unknown fields
+func decodeJSON(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// writeJSON writes v as a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.Error("Failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+// Code generated by cmd/generate-server. DO NOT EDIT.
+
+package fixture
+
+import "time"
+
+// CreateUserRequest is the CreateUserRequest schema
+type CreateUserRequest struct {
+	Email string   `json:"email"`
+	Name  string   `json:"name" example:"Ada Lovelace"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Role is the Role schema
+type Role string
+
+// UpdateRoleRequest is the UpdateRoleRequest schema
+type UpdateRoleRequest struct {
+	Role Role `json:"role" enum:"admin,member"`
+}
+
+// User is the User schema
+type User struct {
+	Address   UserAddress            `json:"address,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	ID        string                 `json:"id"`
+	Level     int64                  `json:"level,omitempty" enum:"1,2,3"`
+	ManagerID *string                `json:"manager_id,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Name      string                 `json:"name"`
+	Role      Role                   `json:"role" enum:"admin,member"`
+}
+
+// UserAddress is the UserAddress schema
+type UserAddress struct {
+	City string `json:"city,omitempty"`
+	Zip  string `json:"zip,omitempty"`
+}
@@ -0,0 +1,130 @@
+// Code generated by cmd/generate-server. DO NOT EDIT.
+
+package fixture
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+// UsersHandler implements the users module. Every method receives the decoded
+// path parameters and body; returning a *types.StatusError selects the error status.
+type UsersHandler interface {
+	// ListUsers serves GET /api/v1/users: List users
+	// Query parameter "limit" is available in query.
+	ListUsers(ctx context.Context, query url.Values) ([]User, error)
+
+	// CreateUser serves POST /api/v1/users: Create a user
+	CreateUser(ctx context.Context, body *CreateUserRequest) (*User, error)
+
+	// DeleteUser serves DELETE /api/v1/users/{user_id}: Delete a user
+	DeleteUser(ctx context.Context, userID string) error
+
+	// GetUser serves GET /api/v1/users/{user_id}: Get a user
+	GetUser(ctx context.Context, userID string) (*User, error)
+
+	// UpdateUserRole serves PATCH /api/v1/users/{user_id}: Change a user's role
+	UpdateUserRole(ctx context.Context, userID string, body *UpdateRoleRequest) (*User, error)
+}
+
+// usersHandler serves the users module; nil until SetUsersHandler is called
+var usersHandler UsersHandler
+
+// SetUsersHandler installs the implementation behind the users routes
+func SetUsersHandler(h UsersHandler) {
+	usersHandler = h
+}
+
+// handleListUsers adapts ListUsers to net/http
+func handleListUsers(w http.ResponseWriter, r *http.Request) {
+	impl := usersHandler
+	if impl == nil {
+		types.WriteError(w, http.StatusNotImplemented, "users handler is not implemented")
+		return
+	}
+
+	resp, err := impl.ListUsers(r.Context(), r.URL.Query())
+	if err != nil {
+		types.WriteStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCreateUser adapts CreateUser to net/http
+func handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	impl := usersHandler
+	if impl == nil {
+		types.WriteError(w, http.StatusNotImplemented, "users handler is not implemented")
+		return
+	}
+
+	var body CreateUserRequest
+	if err := decodeJSON(r, &body); err != nil {
+		types.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := impl.CreateUser(r.Context(), &body)
+	if err != nil {
+		types.WriteStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// handleDeleteUser adapts DeleteUser to net/http
+func handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	impl := usersHandler
+	if impl == nil {
+		types.WriteError(w, http.StatusNotImplemented, "users handler is not implemented")
+		return
+	}
+
+	if err := impl.DeleteUser(r.Context(), r.PathValue("user_id")); err != nil {
+		types.WriteStatusError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetUser adapts GetUser to net/http
+func handleGetUser(w http.ResponseWriter, r *http.Request) {
+	impl := usersHandler
+	if impl == nil {
+		types.WriteError(w, http.StatusNotImplemented, "users handler is not implemented")
+		return
+	}
+
+	resp, err := impl.GetUser(r.Context(), r.PathValue("user_id"))
+	if err != nil {
+		types.WriteStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleUpdateUserRole adapts UpdateUserRole to net/http
+func handleUpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	impl := usersHandler
+	if impl == nil {
+		types.WriteError(w, http.StatusNotImplemented, "users handler is not implemented")
+		return
+	}
+
+	var body UpdateRoleRequest
+	if err := decodeJSON(r, &body); err != nil {
+		types.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := impl.UpdateUserRole(r.Context(), r.PathValue("user_id"), &body)
+	if err != nil {
+		types.WriteStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,59 @@
+// Code generated by cmd/generate-server. DO NOT EDIT.
+
+package fixture
+
+import (
+	"reflect"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+func init() {
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/api/v1/users",
+		Handler:      handleListUsers,
+		ResponseType: reflect.TypeOf([]User{}),
+		Module:       "users",
+		Summary:      "List users",
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:        "POST",
+		Path:          "/api/v1/users",
+		Handler:       handleCreateUser,
+		RequestType:   reflect.TypeOf(CreateUserRequest{}),
+		ResponseType:  reflect.TypeOf(User{}),
+		Module:        "users",
+		Summary:       "Create a user",
+		SuccessStatus: 201,
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:        "DELETE",
+		Path:          "/api/v1/users/{user_id}",
+		Handler:       handleDeleteUser,
+		Module:        "users",
+		Summary:       "Delete a user",
+		SuccessStatus: 204,
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/api/v1/users/{user_id}",
+		Handler:      handleGetUser,
+		ResponseType: reflect.TypeOf(User{}),
+		Module:       "users",
+		Summary:      "Get a user",
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "PATCH",
+		Path:         "/api/v1/users/{user_id}",
+		Handler:      handleUpdateUserRole,
+		RequestType:  reflect.TypeOf(UpdateRoleRequest{}),
+		ResponseType: reflect.TypeOf(User{}),
+		Module:       "users",
+		Summary:      "Change a user's role",
+	})
+}
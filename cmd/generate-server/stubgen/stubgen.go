@@ -0,0 +1,384 @@
+// Package stubgen generates server stubs from an OpenAPI document: Go types,
+// a handler interface per module and init() files that register each
+// operation with types.RegisterRoute.
+package stubgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"{{MODULE_NAME}}/cmd/internal/gotypes"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// Options controls stub generation
+type Options struct {
+	PackageName string // Name of the generated package
+}
+
+// Generator emits Go source for a server stub package
+type Generator struct {
+	doc   *openapi.Document
+	opts  Options
+	types *gotypes.TypeSet
+}
+
+// NewGenerator creates a stub generator for doc
+func NewGenerator(doc *openapi.Document, opts Options) *Generator {
+	if opts.PackageName == "" {
+		opts.PackageName = "stubs"
+	}
+	return &Generator{
+		doc:  doc,
+		opts: opts,
+		// The analyzer names slice types after their element, so array
+		// components are rendered as plain slices to keep their names
+		types: gotypes.NewTypeSet(doc, gotypes.Options{StructTags: true, InlineArrayRefs: true}),
+	}
+}
+
+// operation is a documented operation with everything its stub needs
+type operation struct {
+	openapi.PathOperation
+	name    string // Exported interface method name
+	body    string // Go type of the request body, empty if none
+	result  string // Go type of the success response, empty if none
+	success int    // Success status code
+
+	bodyStruct   bool // The request body is a struct, passed by pointer
+	resultStruct bool // The response is a struct, returned by pointer
+}
+
+// wildcardName matches names http.ServeMux accepts for {name} wildcards
+var wildcardName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Generate returns the gofmt-ed stub files keyed by file name: types.go,
+// server.go and a <module>.go plus <module>_init.go per module
+func (g *Generator) Generate() (map[string][]byte, error) {
+	modules, err := g.collectOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{
+		"server.go": fmt.Sprintf(runtimeSource, g.opts.PackageName),
+	}
+	for _, module := range sortedKeys(modules) {
+		file := moduleFileName(module)
+		if file == "types" || file == "server" {
+			return nil, fmt.Errorf("module %q maps to reserved file %s.go", module, file)
+		}
+		files[file+".go"] = g.renderModule(module, modules[module])
+		files[file+"_init.go"] = g.renderInit(module, modules[module])
+	}
+	// Types are rendered last, once every operation has referenced its schemas
+	files["types.go"] = g.renderTypes()
+
+	out := make(map[string][]byte, len(files))
+	for name, src := range files {
+		formatted, err := format.Source([]byte(src))
+		if err != nil {
+			return nil, fmt.Errorf("generated %s is not valid Go: %w", name, err)
+		}
+		out[name] = formatted
+	}
+	return out, nil
+}
+
+// collectOperations groups the document's operations by module (first tag)
+func (g *Generator) collectOperations() (map[string][]operation, error) {
+	modules := make(map[string][]operation)
+	seen := make(map[string]string)
+
+	for _, pathOp := range g.doc.SortedOperations() {
+		o := operation{PathOperation: pathOp}
+		op := o.Operation
+
+		id := op.OperationID
+		if id == "" {
+			id = strings.ToLower(o.Method) + " " + strings.ReplaceAll(o.Path, "/", " ")
+		}
+		o.name = gotypes.ExportName(id)
+		if previous, dup := seen[o.name]; dup {
+			return nil, fmt.Errorf("operations %s and %s %s both map to method %s", previous, o.Method, o.Path, o.name)
+		}
+		seen[o.name] = o.Method + " " + o.Path
+
+		for _, p := range o.Parameters {
+			if p.In == "path" && !wildcardName.MatchString(p.Name) {
+				return nil, fmt.Errorf("%s %s: path parameter %q is not a valid http.ServeMux wildcard name", o.Method, o.Path, p.Name)
+			}
+		}
+
+		if rb := op.RequestBody; rb != nil {
+			if content, ok := rb.Content["application/json"]; ok && content.Schema != nil {
+				o.body = g.types.GoType(content.Schema, o.name+"Request")
+				o.bodyStruct = g.types.IsStruct(content.Schema) && !strings.HasPrefix(o.body, "*")
+			}
+		}
+		var resultSchema *openapi.Schema
+		o.success, o.result, resultSchema = g.successResponse(op, o.name+"Response")
+		o.resultStruct = g.types.IsStruct(resultSchema) && !strings.HasPrefix(o.result, "*")
+
+		modules[o.Module()] = append(modules[o.Module()], o)
+	}
+	return modules, nil
+}
+
+// successResponse returns the lowest documented 2xx status with its JSON body
+// type and schema
+func (g *Generator) successResponse(op *openapi.Operation, hint string) (int, string, *openapi.Schema) {
+	var codes []int
+	for code := range op.Responses {
+		if status, err := strconv.Atoi(code); err == nil && status >= 200 && status < 300 {
+			codes = append(codes, status)
+		}
+	}
+	if len(codes) == 0 {
+		return 200, "", nil
+	}
+	sort.Ints(codes)
+
+	content, ok := op.Responses[strconv.Itoa(codes[0])].Content["application/json"]
+	if !ok || content.Schema == nil {
+		return codes[0], "", nil
+	}
+	return codes[0], g.types.GoType(content.Schema, hint), content.Schema
+}
+
+// renderTypes renders the request and response types used by operations
+func (g *Generator) renderTypes() string {
+	imports, decls := g.types.Render()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by cmd/generate-server. DO NOT EDIT.\n\npackage %s\n\n", g.opts.PackageName)
+	for _, imp := range imports {
+		fmt.Fprintf(&buf, "import %q\n\n", imp)
+	}
+	buf.WriteString(decls)
+	return buf.String()
+}
+
+// renderModule renders the handler interface and net/http adapters for a module
+func (g *Generator) renderModule(module string, ops []operation) string {
+	iface := gotypes.ExportName(module) + "Handler"
+	implVar := lowerFirst(iface)
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// %s implements the %s module. Every method receives the decoded\n", iface, module)
+	body.WriteString("// path parameters and body; returning a *types.StatusError selects the error status.\n")
+	fmt.Fprintf(&body, "type %s interface {\n", iface)
+	for i, o := range ops {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+		summary := o.Operation.Summary
+		if summary == "" {
+			summary = "handles " + o.Method + " " + o.Path
+		}
+		fmt.Fprintf(&body, "\t// %s serves %s %s: %s\n", o.name, o.Method, o.Path, summary)
+		for _, q := range o.Parameters {
+			if q.In == "query" {
+				fmt.Fprintf(&body, "\t// Query parameter %q is available in query.\n", q.Name)
+			}
+		}
+		fmt.Fprintf(&body, "\t%s(%s) %s\n", o.name, strings.Join(signatureArgs(o), ", "), resultList(o))
+	}
+	body.WriteString("}\n\n")
+
+	fmt.Fprintf(&body, "// %s serves the %s module; nil until Set%s is called\n", implVar, module, iface)
+	fmt.Fprintf(&body, "var %s %s\n\n", implVar, iface)
+	fmt.Fprintf(&body, "// Set%s installs the implementation behind the %s routes\n", iface, module)
+	fmt.Fprintf(&body, "func Set%s(h %s) {\n\t%s = h\n}\n", iface, iface, implVar)
+
+	for _, o := range ops {
+		g.renderAdapter(&body, module, implVar, o)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by cmd/generate-server. DO NOT EDIT.\n\npackage %s\n\n", g.opts.PackageName)
+	imports := []string{"context", "net/http"}
+	if strings.Contains(body.String(), "url.Values") {
+		imports = append(imports, "net/url")
+	}
+	buf.WriteString("import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&buf, "\t%q\n", imp)
+	}
+	buf.WriteString("\n\t\"{{MODULE_NAME}}/internal/api/types\"\n)\n\n")
+	buf.Write(body.Bytes())
+	return buf.String()
+}
+
+// renderAdapter renders the http.HandlerFunc that decodes a request, calls
+// the interface method and encodes its result
+func (g *Generator) renderAdapter(buf *bytes.Buffer, module, implVar string, o operation) {
+	fmt.Fprintf(buf, "\n// %s adapts %s to net/http\n", adapterName(o), o.name)
+	fmt.Fprintf(buf, "func %s(w http.ResponseWriter, r *http.Request) {\n", adapterName(o))
+	fmt.Fprintf(buf, "\timpl := %s\n\tif impl == nil {\n", implVar)
+	fmt.Fprintf(buf, "\t\ttypes.WriteError(w, http.StatusNotImplemented, %q)\n\t\treturn\n\t}\n", module+" handler is not implemented")
+
+	args := []string{"r.Context()"}
+	for _, p := range o.Parameters {
+		if p.In == "path" {
+			args = append(args, fmt.Sprintf("r.PathValue(%q)", p.Name))
+		}
+	}
+	if hasQuery(o) {
+		args = append(args, "r.URL.Query()")
+	}
+	if o.body != "" {
+		fmt.Fprintf(buf, "\n\tvar body %s\n", o.body)
+		buf.WriteString("\tif err := decodeJSON(r, &body); err != nil {\n\t\ttypes.WriteError(w, http.StatusBadRequest, err.Error())\n\t\treturn\n\t}\n")
+		if o.bodyStruct {
+			args = append(args, "&body")
+		} else {
+			args = append(args, "body")
+		}
+	}
+
+	call := fmt.Sprintf("impl.%s(%s)", o.name, strings.Join(args, ", "))
+	if o.result == "" {
+		fmt.Fprintf(buf, "\n\tif err := %s; err != nil {\n\t\ttypes.WriteStatusError(w, err)\n\t\treturn\n\t}\n", call)
+		fmt.Fprintf(buf, "\tw.WriteHeader(%s)\n}\n", statusConst(o.success))
+		return
+	}
+	fmt.Fprintf(buf, "\n\tresp, err := %s\n\tif err != nil {\n\t\ttypes.WriteStatusError(w, err)\n\t\treturn\n\t}\n", call)
+	fmt.Fprintf(buf, "\twriteJSON(w, %s, resp)\n}\n", statusConst(o.success))
+}
+
+// renderInit renders the init() that registers a module's routes
+func (g *Generator) renderInit(module string, ops []operation) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by cmd/generate-server. DO NOT EDIT.\n\npackage %s\n\n", g.opts.PackageName)
+	needsReflect := false
+	for _, o := range ops {
+		needsReflect = needsReflect || o.body != "" || o.result != ""
+	}
+	buf.WriteString("import (\n")
+	if needsReflect {
+		buf.WriteString("\t\"reflect\"\n\n")
+	}
+	buf.WriteString("\t\"{{MODULE_NAME}}/internal/api/types\"\n)\n\n")
+
+	buf.WriteString("func init() {\n")
+	for i, o := range ops {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\ttypes.RegisterRoute(types.RouteInfo{\n")
+		fmt.Fprintf(&buf, "\t\tMethod: %q,\n\t\tPath: %q,\n\t\tHandler: %s,\n", o.Method, o.Path, adapterName(o))
+		if o.body != "" {
+			fmt.Fprintf(&buf, "\t\tRequestType: %s,\n", reflectType(o.body, o.bodyStruct))
+		}
+		if o.result != "" {
+			fmt.Fprintf(&buf, "\t\tResponseType: %s,\n", reflectType(o.result, o.resultStruct))
+		}
+		fmt.Fprintf(&buf, "\t\tModule: %q,\n", module)
+		if o.Operation.Summary != "" {
+			fmt.Fprintf(&buf, "\t\tSummary: %q,\n", o.Operation.Summary)
+		}
+		if o.success != 200 {
+			fmt.Fprintf(&buf, "\t\tSuccessStatus: %s,\n", strconv.Itoa(o.success))
+		}
+		buf.WriteString("\t})\n")
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// signatureArgs returns the parameters of an interface method
+func signatureArgs(o operation) []string {
+	args := []string{"ctx context.Context"}
+	for _, p := range o.Parameters {
+		if p.In == "path" {
+			args = append(args, gotypes.ParamName(p.Name)+" string")
+		}
+	}
+	if hasQuery(o) {
+		args = append(args, "query url.Values")
+	}
+	if o.body != "" {
+		if o.bodyStruct {
+			args = append(args, "body *"+o.body)
+		} else {
+			args = append(args, "body "+o.body)
+		}
+	}
+	return args
+}
+
+// resultList returns the results of an interface method
+func resultList(o operation) string {
+	if o.result == "" {
+		return "error"
+	}
+	if o.resultStruct {
+		return "(*" + o.result + ", error)"
+	}
+	return "(" + o.result + ", error)"
+}
+
+// reflectType renders a reflect.Type expression for a Go type
+func reflectType(t string, isStruct bool) string {
+	if isStruct || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") {
+		return "reflect.TypeOf(" + t + "{})"
+	}
+	return "reflect.TypeOf((*" + t + ")(nil)).Elem()"
+}
+
+// hasQuery reports whether an operation documents query parameters
+func hasQuery(o operation) bool {
+	for _, p := range o.Parameters {
+		if p.In == "query" {
+			return true
+		}
+	}
+	return false
+}
+
+// adapterName returns the name of an operation's http.HandlerFunc
+func adapterName(o operation) string {
+	return "handle" + o.name
+}
+
+// statusConst renders a status code as its net/http constant when one exists
+func statusConst(status int) string {
+	if name, ok := statusNames[status]; ok {
+		return "http." + name
+	}
+	return strconv.Itoa(status)
+}
+
+// statusNames maps common success codes to their net/http constant names
+var statusNames = map[int]string{
+	200: "StatusOK",
+	201: "StatusCreated",
+	202: "StatusAccepted",
+	204: "StatusNoContent",
+}
+
+// moduleFileName returns the file name, without extension, for a module
+func moduleFileName(module string) string {
+	return strings.ToLower(gotypes.ExportName(module))
+}
+
+// lowerFirst lower-cases the first letter of an identifier
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// sortedKeys returns the keys of a module map in order
+func sortedKeys(m map[string][]operation) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
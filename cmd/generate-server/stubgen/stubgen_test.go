@@ -0,0 +1,240 @@
+package stubgen
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/cmd/generate-openapi/analyzer"
+	"{{MODULE_NAME}}/cmd/generate-server/stubgen/internal/fixture"
+	"{{MODULE_NAME}}/cmd/internal/golden"
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureDir holds the stubs generated from testdata/spec.yaml; it doubles as
+// the golden output and as the package the round-trip test registers
+var fixtureDir = filepath.Join("internal", "fixture")
+
+func loadFixtureSpec(t *testing.T) *openapi.Document {
+	doc, err := openapi.LoadFile(filepath.Join("testdata", "spec.yaml"))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestGenerateGolden(t *testing.T) {
+	files, err := NewGenerator(loadFixtureSpec(t), Options{PackageName: "fixture"}).Generate()
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"default.go", "default_init.go", "server.go", "types.go", "users.go", "users_init.go"}, names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			golden.Assert(t, filepath.Join(fixtureDir, name), files[name])
+		})
+	}
+}
+
+// TestRoundTrip checks that the analyzer, run over the routes registered by
+// the generated init() files, reproduces the operations of the source spec
+func TestRoundTrip(t *testing.T) {
+	original := loadFixtureSpec(t)
+
+	jsonSpec, err := analyzer.NewGenerator().GenerateJSONSpec()
+	require.NoError(t, err)
+	regenerated, err := openapi.ParseJSON([]byte(jsonSpec))
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, original.SortedPaths(), regenerated.SortedPaths())
+	for _, path := range original.SortedPaths() {
+		want := original.Paths[path].Operations()
+		got := regenerated.Paths[path].Operations()
+		require.Len(t, got, len(want), path)
+
+		for method, wantOp := range want {
+			gotOp := got[method]
+			require.NotNil(t, gotOp, "%s %s", method, path)
+			label := method + " " + path
+
+			assert.Equal(t, tagOrDefault(wantOp.Tags), tagOrDefault(gotOp.Tags), label)
+			assert.Equal(t, wantOp.Summary, gotOp.Summary, label)
+			assert.Equal(t, pathParamNames(original.Paths[path], wantOp), pathParamNames(regenerated.Paths[path], gotOp), label)
+
+			wantStatus, wantSchema := successSchema(wantOp)
+			gotStatus, gotSchema := successSchema(gotOp)
+			assert.Equal(t, wantStatus, gotStatus, label)
+			assert.Equal(t, normalize(original, wantSchema), normalize(regenerated, gotSchema), label+" response")
+			assert.Equal(t, normalize(original, requestSchema(wantOp)), normalize(regenerated, requestSchema(gotOp)), label+" request")
+		}
+	}
+}
+
+// fakeUsers is a minimal UsersHandler used to exercise the adapters
+type fakeUsers struct {
+	fixture.UsersHandler
+}
+
+func (fakeUsers) CreateUser(ctx context.Context, body *fixture.CreateUserRequest) (*fixture.User, error) {
+	if body.Email == "taken@example.com" {
+		return nil, types.NewStatusError(http.StatusConflict, "email %s is taken", body.Email)
+	}
+	return &fixture.User{ID: "u1", Name: body.Name, Role: "member"}, nil
+}
+
+func (fakeUsers) DeleteUser(ctx context.Context, userID string) error {
+	if userID != "u1" {
+		return types.NewStatusError(http.StatusNotFound, "user %s not found", userID)
+	}
+	return nil
+}
+
+func TestAdapters(t *testing.T) {
+	routes := make(map[string]types.RouteInfo)
+	for _, route := range types.GetRegisteredRoutes() {
+		routes[route.Method+" "+route.Path] = route
+	}
+	create := routes["POST /api/v1/users"].Handler
+	remove := routes["DELETE /api/v1/users/{user_id}"].Handler
+	require.NotNil(t, create)
+	require.NotNil(t, remove)
+
+	serve := func(handler http.HandlerFunc, method, body string, pathValue string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.SetPathValue("user_id", pathValue)
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	fixture.SetUsersHandler(nil)
+	assert.Equal(t, http.StatusNotImplemented, serve(create, "POST", `{}`, "").Code)
+
+	fixture.SetUsersHandler(fakeUsers{})
+	defer fixture.SetUsersHandler(nil)
+
+	w := serve(create, "POST", `{"name":"Ada","email":"ada@example.com"}`, "")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var user fixture.User
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	assert.Equal(t, "Ada", user.Name)
+
+	w = serve(create, "POST", `{"name":"Ada","email":"taken@example.com"}`, "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "email taken@example.com is taken")
+
+	assert.Equal(t, http.StatusBadRequest, serve(create, "POST", `{"unknown":1}`, "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(remove, "DELETE", "", "u1").Code)
+	assert.Equal(t, http.StatusNotFound, serve(remove, "DELETE", "", "u2").Code)
+}
+
+func TestGenerateRejectsInvalidWildcards(t *testing.T) {
+	doc := &openapi.Document{Paths: map[string]openapi.PathItem{
+		"/items/{item-id}": {Get: &openapi.Operation{
+			OperationID: "getItem",
+			Parameters:  []openapi.Parameter{{Name: "item-id", In: "path", Required: true}},
+		}},
+	}}
+
+	_, err := NewGenerator(doc, Options{}).Generate()
+	assert.ErrorContains(t, err, "item-id")
+}
+
+func tagOrDefault(tags []string) string {
+	if len(tags) == 0 || tags[0] == "" {
+		return "default"
+	}
+	return tags[0]
+}
+
+func pathParamNames(item openapi.PathItem, op *openapi.Operation) []string {
+	var names []string
+	for _, p := range append(append([]openapi.Parameter{}, item.Parameters...), op.Parameters...) {
+		if p.In == "path" {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+func successSchema(op *openapi.Operation) (string, *openapi.Schema) {
+	var codes []string
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	if len(codes) == 0 {
+		return "", nil
+	}
+	if content, ok := op.Responses[codes[0]].Content["application/json"]; ok {
+		return codes[0], content.Schema
+	}
+	return codes[0], nil
+}
+
+func requestSchema(op *openapi.Operation) *openapi.Schema {
+	if op.RequestBody == nil {
+		return nil
+	}
+	return op.RequestBody.Content["application/json"].Schema
+}
+
+// normalize resolves references and drops documentation-only keywords so
+// schemas from both documents can be compared structurally
+func normalize(doc *openapi.Document, schema *openapi.Schema) interface{} {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		return normalize(doc, doc.ResolveSchema(schema))
+	}
+
+	out := map[string]interface{}{"type": schema.Type}
+	if schema.Format != "" {
+		out["format"] = schema.Format
+	}
+	if schema.Nullable {
+		out["nullable"] = true
+	}
+	if len(schema.Enum) > 0 {
+		out["enum"] = jsonValue(schema.Enum)
+	}
+	if schema.Example != nil {
+		out["example"] = jsonValue(schema.Example)
+	}
+	if schema.Items != nil {
+		out["items"] = normalize(doc, schema.Items)
+	}
+	if len(schema.Properties) > 0 {
+		props := make(map[string]interface{})
+		for name, prop := range schema.Properties {
+			props[name] = normalize(doc, prop)
+		}
+		out["properties"] = props
+		required := append([]string{}, schema.Required...)
+		sort.Strings(required)
+		out["required"] = required
+	}
+	return out
+}
+
+// jsonValue normalizes numeric types by round-tripping through JSON
+func jsonValue(v interface{}) interface{} {
+	data, _ := json.Marshal(v)
+	var out interface{}
+	json.Unmarshal(data, &out)
+	return out
+}
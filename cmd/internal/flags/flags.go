@@ -0,0 +1,16 @@
+// Package flags parses command-line flag values shared by the generators
+// under cmd.
+package flags
+
+import "strings"
+
+// SplitList splits a comma-separated flag value, dropping empty entries
+func SplitList(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,12 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"docs", "debug"}, SplitList(" docs, ,debug,"))
+	assert.Nil(t, SplitList(""))
+}
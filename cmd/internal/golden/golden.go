@@ -0,0 +1,27 @@
+// Package golden compares generator output with golden files in tests.
+// Run go test -update to rewrite the golden files.
+package golden
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "Rewrite golden files")
+
+// Assert fails the test when got differs from the golden file at path,
+// rewriting the file first when -update is set
+func Assert(t *testing.T, path string, got []byte) {
+	t.Helper()
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test -update to create golden files")
+	assert.Equal(t, string(expected), string(got))
+}
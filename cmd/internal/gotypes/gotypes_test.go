@@ -0,0 +1,68 @@
+package gotypes
+
+import (
+	"testing"
+
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":             "UserID",
+		"gethealth":           "Gethealth",
+		"getapiV1UsersUserId": "GetapiV1UsersUserID",
+		"created_at":          "CreatedAt",
+		"2fa":                 "N2fa",
+		"":                    "Value",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, ExportName(input), input)
+	}
+}
+
+func TestParamName(t *testing.T) {
+	assert.Equal(t, "userID", ParamName("user_id"))
+	assert.Equal(t, "id", ParamName("id"))
+	assert.Equal(t, "typeParam", ParamName("type"))
+	assert.Equal(t, "bodyParam", ParamName("body"))
+}
+
+func testDocument() *openapi.Document {
+	return &openapi.Document{Components: openapi.Components{Schemas: map[string]*openapi.Schema{
+		"Role": {Type: "string", Enum: []interface{}{"admin", "member"}},
+		"User": {Type: "object", Required: []string{"name"}, Properties: map[string]*openapi.Schema{
+			"name":    {Type: "string", Example: "Ada"},
+			"role":    {Ref: "#/components/schemas/Role"},
+			"address": {Type: "object", Properties: map[string]*openapi.Schema{"city": {Type: "string"}}},
+		}},
+		"UserArray": {Type: "array", Items: &openapi.Schema{Ref: "#/components/schemas/User"}},
+	}}}
+}
+
+func TestGoTypeInlineArrayRefs(t *testing.T) {
+	ref := &openapi.Schema{Ref: "#/components/schemas/UserArray"}
+
+	assert.Equal(t, "UserArray", NewTypeSet(testDocument(), Options{}).GoType(ref, ""))
+	assert.Equal(t, "[]User", NewTypeSet(testDocument(), Options{InlineArrayRefs: true}).GoType(ref, ""))
+}
+
+func TestRenderStructTags(t *testing.T) {
+	set := NewTypeSet(testDocument(), Options{StructTags: true})
+	set.GoType(&openapi.Schema{Ref: "#/components/schemas/User"}, "")
+
+	_, decls := set.Render()
+	assert.Contains(t, decls, "type Role string")
+	assert.Contains(t, decls, "Name string `json:\"name\" example:\"Ada\"`")
+	assert.Contains(t, decls, "Role Role `json:\"role,omitempty\" enum:\"admin,member\"`")
+	assert.Contains(t, decls, "Address UserAddress `json:\"address,omitempty\"`")
+}
+
+func TestRenderOptionalStructPointers(t *testing.T) {
+	set := NewTypeSet(testDocument(), Options{OptionalStructPointers: true})
+	set.GoType(&openapi.Schema{Ref: "#/components/schemas/User"}, "")
+
+	_, decls := set.Render()
+	assert.Contains(t, decls, "Address *UserAddress `json:\"address,omitempty\"`")
+	assert.NotContains(t, decls, "enum:")
+}
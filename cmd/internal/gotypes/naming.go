@@ -0,0 +1,90 @@
+package gotypes
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are upper-cased as a whole, following Go naming conventions
+var initialisms = map[string]bool{
+	"API": true, "HTTP": true, "ID": true, "JSON": true, "URL": true, "URI": true,
+	"UUID": true, "IP": true, "HTML": true, "XML": true, "YAML": true, "TLS": true,
+}
+
+// ExportName converts a JSON property, schema or operation name to an
+// exported Go identifier, e.g. "user_id" -> "UserID", "gethealth" -> "Gethealth"
+func ExportName(name string) string {
+	words := splitWords(name)
+	var b strings.Builder
+	for _, word := range words {
+		upper := strings.ToUpper(word)
+		if initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	out := b.String()
+	if out == "" {
+		return "Value"
+	}
+	if unicode.IsDigit(rune(out[0])) {
+		out = "N" + out
+	}
+	return out
+}
+
+// ParamName converts a parameter name to an unexported Go identifier that is
+// not a keyword or a name used by generated code
+func ParamName(name string) string {
+	exported := ExportName(name)
+	for word := range initialisms {
+		if strings.HasPrefix(exported, word) && (len(exported) == len(word) || unicode.IsUpper(rune(exported[len(word)]))) {
+			exported = strings.ToLower(word) + exported[len(word):]
+			break
+		}
+	}
+	out := strings.ToLower(exported[:1]) + exported[1:]
+	if goKeywords[out] {
+		out += "Param"
+	}
+	return out
+}
+
+// splitWords splits on separators and lower-to-upper case transitions
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			if len(current) > 0 {
+				words = append(words, string(current))
+			}
+			current = []rune{r}
+		default:
+			current = append(current, r)
+		}
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// goKeywords may not be used as parameter names
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true,
+	"goto": true, "if": true, "import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true, "switch": true, "type": true,
+	"var": true, "ctx": true, "body": true, "query": true, "out": true, "c": true,
+	"w": true, "r": true, "err": true, "resp": true, "impl": true,
+}
@@ -0,0 +1,236 @@
+// Package gotypes maps OpenAPI schemas to Go type declarations for the code
+// generators under cmd.
+package gotypes
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// Options controls how schemas are mapped to Go types
+type Options struct {
+	// StructTags adds `enum` and `example` tags so the analyzer can
+	// reproduce the schema from the generated types
+	StructTags bool
+	// InlineArrayRefs renders references to array components as slices
+	// instead of declaring a named slice type
+	InlineArrayRefs bool
+	// OptionalStructPointers renders optional struct fields as pointers so
+	// omitempty takes effect
+	OptionalStructPointers bool
+}
+
+// TypeSet collects the Go declarations needed for a set of schemas
+type TypeSet struct {
+	doc     *openapi.Document
+	opts    Options
+	types   map[string]string // Go type name -> declaration
+	pending []string          // Component names queued for declaration
+}
+
+// NewTypeSet creates an empty type set for doc
+func NewTypeSet(doc *openapi.Document, opts Options) *TypeSet {
+	return &TypeSet{doc: doc, opts: opts, types: make(map[string]string)}
+}
+
+// Declare reserves a named type with a fixed declaration
+func (s *TypeSet) Declare(name, decl string) {
+	s.types[name] = decl
+}
+
+// Has reports whether a component schema is defined in the document
+func (s *TypeSet) Has(component string) bool {
+	_, ok := s.doc.Components.Schemas[component]
+	return ok
+}
+
+// Render returns the declarations of every type used so far, sorted by name,
+// along with the imports they need
+func (s *TypeSet) Render() (imports []string, decls string) {
+	// Declaring a type may queue more types for nested schemas
+	for len(s.pending) > 0 {
+		name := s.pending[0]
+		s.pending = s.pending[1:]
+		s.declareComponent(name)
+	}
+
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		buf.WriteString(s.types[name])
+		buf.WriteString("\n")
+	}
+
+	if strings.Contains(buf.String(), "time.Time") {
+		imports = append(imports, "time")
+	}
+	return imports, buf.String()
+}
+
+// GoType returns the Go type for a schema, queueing named types for declaration.
+// hint names inline object types.
+func (s *TypeSet) GoType(schema *openapi.Schema, hint string) string {
+	if schema == nil {
+		return "interface{}"
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		if target := s.doc.Components.Schemas[name]; s.opts.InlineArrayRefs && target != nil && target.Type == "array" {
+			return s.GoType(target, name)
+		}
+		typeName := ExportName(name)
+		if _, declared := s.types[typeName]; !declared {
+			s.types[typeName] = "" // reserve the name while the declaration is pending
+			s.pending = append(s.pending, name)
+		}
+		return typeName
+	}
+
+	var t string
+	switch schema.Type {
+	case "string":
+		t = "string"
+		if schema.Format == "date-time" {
+			t = "time.Time"
+		}
+	case "integer":
+		t = "int64"
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		t = "[]" + s.GoType(schema.Items, strings.TrimSuffix(hint, "Array")+"Item")
+	case "object":
+		if len(schema.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		typeName := ExportName(hint)
+		if _, declared := s.types[typeName]; !declared {
+			s.types[typeName] = "" // reserve the name for self-referencing fields
+			s.types[typeName] = s.structDecl(typeName, schema)
+		}
+		t = typeName
+	default:
+		t = "interface{}"
+	}
+
+	if schema.Nullable && !strings.HasPrefix(t, "[]") && !strings.HasPrefix(t, "map[") && t != "interface{}" {
+		return "*" + t
+	}
+	return t
+}
+
+// IsStruct reports whether a schema is rendered as a Go struct
+func (s *TypeSet) IsStruct(schema *openapi.Schema) bool {
+	if schema != nil && schema.Ref != "" {
+		schema = s.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema != nil && schema.Type == "object" && len(schema.Properties) > 0
+}
+
+// declareComponent declares the Go type for a named component schema
+func (s *TypeSet) declareComponent(name string) {
+	typeName := ExportName(name)
+	schema := s.doc.Components.Schemas[name]
+	if schema == nil {
+		s.types[typeName] = fmt.Sprintf("// %s is referenced but not defined in the spec\ntype %s = interface{}\n", typeName, typeName)
+		return
+	}
+
+	if schema.Type == "object" && len(schema.Properties) > 0 {
+		s.types[typeName] = s.structDecl(typeName, schema)
+		return
+	}
+
+	hint := typeName
+	if schema.Type == "array" {
+		hint = strings.TrimSuffix(typeName, "Array")
+		if hint == typeName {
+			hint += "Item"
+		}
+		s.types[typeName] = fmt.Sprintf("// %s is the %s schema\ntype %s []%s\n", typeName, name, typeName, s.GoType(schema.Items, hint))
+		return
+	}
+	s.types[typeName] = fmt.Sprintf("// %s is the %s schema\ntype %s %s\n", typeName, name, typeName, s.GoType(&openapi.Schema{Type: schema.Type, Format: schema.Format}, hint))
+}
+
+// structDecl renders a struct declaration for an object schema
+func (s *TypeSet) structDecl(typeName string, schema *openapi.Schema) string {
+	required := make(map[string]bool)
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+
+	props := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		props = append(props, name)
+	}
+	sort.Strings(props)
+
+	var buf bytes.Buffer
+	if schema.Description != "" {
+		fmt.Fprintf(&buf, "// %s %s\n", typeName, schema.Description)
+	} else {
+		fmt.Fprintf(&buf, "// %s is the %s schema\n", typeName, typeName)
+	}
+	fmt.Fprintf(&buf, "type %s struct {\n", typeName)
+	for _, prop := range props {
+		propSchema := schema.Properties[prop]
+		fieldType := s.GoType(propSchema, typeName+ExportName(prop))
+		tag := "json:" + strconv.Quote(prop)
+		if !required[prop] {
+			tag = "json:" + strconv.Quote(prop+",omitempty")
+			// omitempty has no effect on struct values
+			if s.opts.OptionalStructPointers && s.IsStruct(propSchema) && !strings.HasPrefix(fieldType, "*") {
+				fieldType = "*" + fieldType
+			}
+		}
+		if s.opts.StructTags {
+			tag += s.extraTags(propSchema)
+		}
+		comment := ""
+		if desc := propSchema.Description; desc != "" {
+			comment = " // " + strings.ReplaceAll(desc, "\n", " ")
+		}
+		fmt.Fprintf(&buf, "\t%s %s `%s`%s\n", ExportName(prop), fieldType, tag, comment)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// extraTags renders `enum` and `example` struct tags for a property schema
+func (s *TypeSet) extraTags(schema *openapi.Schema) string {
+	if schema.Ref != "" {
+		if target := s.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]; target != nil && target.Type != "object" && target.Type != "array" {
+			schema = target
+		}
+	}
+
+	var tags string
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, v := range schema.Enum {
+			values[i] = fmt.Sprint(v)
+		}
+		tags += " enum:" + strconv.Quote(strings.Join(values, ","))
+	}
+	switch example := schema.Example.(type) {
+	case nil:
+	case string:
+		tags += " example:" + strconv.Quote(example)
+	case bool, int, int64, float64:
+		tags += " example:" + strconv.Quote(fmt.Sprint(example))
+	}
+	return tags
+}
@@ -2,20 +2,50 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"{{MODULE_NAME}}/internal/api/handler"
+	"{{MODULE_NAME}}/internal/api/validation"
+	"{{MODULE_NAME}}/internal/apispec"
+	"{{MODULE_NAME}}/internal/buildinfo"
 	"{{MODULE_NAME}}/internal/config"
+	"{{MODULE_NAME}}/internal/lifecycle"
 	"{{MODULE_NAME}}/internal/logging"
+	"{{MODULE_NAME}}/internal/mock"
+	"{{MODULE_NAME}}/internal/openapi"
+	"{{MODULE_NAME}}/internal/server"
 )
 
 func main() {
-	logging.Info("Starting TEMPLATE_GOAPI API server")
+	var (
+		mockMode = flag.Bool("mock", false, "Serve synthesized responses for every documented route instead of the real handlers")
+		mockSpec = flag.String("mock-spec", "", "OpenAPI file to mock instead of the route registry (implies -mock)")
+	)
+	flag.Parse()
+
+	logging.Info("Starting TEMPLATE_GOAPI API server %s", buildinfo.Get())
+
+	// Load and validate the server configuration before anything is started
+	serverConfig, err := server.LoadConfig()
+	if err != nil {
+		logging.Error("Invalid server configuration: %v", err)
+		os.Exit(1)
+	}
+	if err := serverConfig.ApplyWriteTimeouts(); err != nil {
+		logging.Error("Invalid server configuration: %v", err)
+		os.Exit(1)
+	}
+
+	// Probes and docs live reload are operational endpoints like profiling
+	if serverConfig.AdminEnabled() {
+		handler.UseAdminListener(serverConfig.AdminPublicProbes)
+	}
 
 	// Initialize handler registry
 	handlerRegistry, err := handler.NewHandlerRegistry()
@@ -24,46 +54,193 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Get server configuration
-	port := config.GetString("server_port")
-	if port == "" {
-		port = "8080" // Default port
+	inFlight := server.NewTracker()
+	var rootHandler http.Handler = handlerRegistry.GetServeMux()
+
+	// In mock mode, documented routes return synthesized responses
+	if *mockMode || *mockSpec != "" {
+		mockHandler, err := newMockHandler(*mockSpec, rootHandler)
+		if err != nil {
+			logging.Error("Failed to initialize mock mode: %v", err)
+			os.Exit(1)
+		}
+		rootHandler = mockHandler
+	}
+
+	// Optionally validate traffic against the generated OpenAPI document
+	if config.GetBool(config.ValidationEnabledKey) {
+		specPath := config.GetString(config.ValidationSpecPathKey)
+		doc, err := openapi.LoadFile(specPath)
+		if err != nil {
+			logging.Error("Failed to load OpenAPI document for validation: %v", err)
+			os.Exit(1)
+		}
+		rootHandler = validation.Middleware(doc, validation.Options{
+			ValidateResponses: config.GetBool(config.ValidationResponsesKey),
+			Strict:            config.GetBool(config.ValidationStrictKey),
+			MaxBodyBytes:      serverConfig.MaxBodyBytes,
+		})(rootHandler)
+		logging.Info("OpenAPI request validation enabled using %s", specPath)
 	}
 
 	// Create HTTP server
-	server := &http.Server{
-		Addr:         fmt.Sprintf(":%s", port),
-		Handler:      handlerRegistry.GetServeMux(),
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-		IdleTimeout:  120 * time.Second,
+	httpServer := serverConfig.NewHTTPServer(inFlight.Middleware(rootHandler))
+	// Open docs pages would otherwise hold their event streams until the shutdown timeout
+	httpServer.RegisterOnShutdown(handler.CloseDocsStreams)
+	tlsCtx, stopTLSReload := context.WithCancel(context.Background())
+	defer stopTLSReload()
+	if err := serverConfig.ConfigureTLS(tlsCtx, httpServer); err != nil {
+		logging.Error("Failed to configure TLS: %v", err)
+		os.Exit(1)
+	}
+
+	// Start background components before accepting traffic
+	readiness := handlerRegistry.GetHealthHandler().Runner()
+	components, err := lifecycle.New(lifecycle.Options{Readiness: readiness})
+	if err != nil {
+		logging.Error("Invalid component registrations: %v", err)
+		os.Exit(1)
+	}
+	if err := components.Start(context.Background()); err != nil {
+		logging.Error("Failed to start components: %v", err)
+		os.Exit(1)
+	}
+
+	// Open every listener before serving either, so a failure leaves no
+	// server running
+	listener, err := serverConfig.Listen()
+	if err != nil {
+		logging.Error("Failed to listen on %s: %v", serverConfig.Addr(), err)
+		_ = components.Stop(context.Background())
+		os.Exit(1)
+	}
+
+	// Serve admin-only routes such as /debug/pprof/ on their own listener
+	var adminServer *http.Server
+	var adminListener net.Listener
+	if serverConfig.AdminEnabled() {
+		adminServer = serverConfig.NewHTTPServer(handlerRegistry.GetAdminServeMux())
+		adminServer.RegisterOnShutdown(handler.CloseDocsStreams)
+		adminListener, err = serverConfig.ListenAdmin()
+		if err != nil {
+			logging.Error("Failed to listen on admin address %s: %v", serverConfig.AdminAddress, err)
+			_ = listener.Close()
+			_ = components.Stop(context.Background())
+			os.Exit(1)
+		}
+	} else {
+		logging.Info("Admin-only routes are disabled; set %s or pass a socket named %q to serve them", config.ServerAdminAddressKey, server.AdminSocketName)
 	}
 
-	// Start server in a goroutine
+	// Start servers in goroutines
 	go func() {
-		logging.Info("TEMPLATE_GOAPI API server starting on port %s", port)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logging.Error("Server failed to start: %v", err)
+		logging.Info("TEMPLATE_GOAPI API server listening on %s", listener.Addr())
+		if err := server.Serve(httpServer, listener); err != nil && err != http.ErrServerClosed {
+			logging.Error("Server failed: %v", err)
 			os.Exit(1)
 		}
 	}()
+	if adminServer != nil {
+		go func() {
+			logging.Info("Admin listener on %s", adminListener.Addr())
+			if err := server.Serve(adminServer, adminListener); err != nil && err != http.ErrServerClosed {
+				logging.Error("Admin server failed: %v", err)
+				os.Exit(1)
+			}
+		}()
+	}
 
-	// Wait for interrupt signal to gracefully shutdown the server
+	// Wait for interrupt signal to gracefully shutdown the server, or for the
+	// upgrade signal to hand the listeners to a new process first. Handlers are
+	// registered before reporting readiness so an early signal is not lost.
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	upgrade := make(chan os.Signal, 1)
+	server.NotifyUpgrade(upgrade)
+
+	// Tell the process that started this one during an upgrade that it can exit
+	if err := server.NotifyUpgradeReady(); err != nil {
+		logging.Warn("Failed to report readiness to the previous process: %v", err)
+	}
+
+	drainDelay := serverConfig.DrainDelay
+	var componentErr error
+wait:
+	for {
+		select {
+		case <-quit:
+			break wait
+		case componentErr = <-components.Failed():
+			logging.Error("Shutting down after a critical component failed: %v", componentErr)
+			break wait
+		case <-upgrade:
+			logging.Info("Upgrading TEMPLATE_GOAPI API server...")
+			child, err := server.Upgrade(context.Background(), server.UpgradeOptions{
+				Public:  listener,
+				Admin:   adminListener,
+				Timeout: serverConfig.UpgradeTimeout,
+			})
+			if err != nil {
+				logging.Error("Upgrade failed, still serving: %v", err)
+				continue
+			}
+			logging.Info("Process %d took over the listeners", child.Pid)
+			// The listening sockets stay open in the new process, so there is
+			// nothing for load balancers to notice
+			drainDelay = 0
+			break wait
+		}
+	}
 
 	logging.Info("Shutting down TEMPLATE_GOAPI API server...")
 
-	// Create a deadline for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// Drain traffic, wait for in-flight requests, then stop components and
+	// the admin listener
+	err = server.Shutdown(context.Background(), server.ShutdownOptions{
+		Server:     httpServer,
+		Admin:      adminServer,
+		Readiness:  readiness,
+		DrainDelay: drainDelay,
+		Timeout:    serverConfig.ShutdownTimeout,
+		InFlight:   inFlight,
+		Components: components,
+	})
+	if err != nil {
+		logging.Error("Server shutdown did not complete cleanly: %v", err)
+	}
 
-	// Attempt graceful shutdown
-	if err := server.Shutdown(ctx); err != nil {
-		logging.Error("Server forced to shutdown: %v", err)
+	logging.Info("TEMPLATE_GOAPI API server stopped")
+	// Flush buffered log entries; syncing stdout fails on some platforms
+	_ = logging.Sync()
+	if err != nil || componentErr != nil {
 		os.Exit(1)
 	}
+}
 
-	logging.Info("TEMPLATE_GOAPI API server stopped")
+// newMockHandler builds the mock handler from specPath, or from the route
+// registry when specPath is empty. Registry mocks keep serving the real
+// documentation routes.
+func newMockHandler(specPath string, registryHandler http.Handler) (http.Handler, error) {
+	if specPath != "" {
+		doc, err := openapi.LoadFile(specPath)
+		if err != nil {
+			return nil, err
+		}
+		logging.Info("Mock mode enabled using %s", specPath)
+		return mock.NewHandler(doc, mock.Options{}), nil
+	}
+
+	jsonSpec, err := apispec.NewGenerator().GenerateJSONSpec()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate spec from route registry: %w", err)
+	}
+	doc, err := openapi.ParseJSON([]byte(jsonSpec))
+	if err != nil {
+		return nil, err
+	}
+	logging.Info("Mock mode enabled for %d registered paths", len(doc.Paths))
+	return mock.NewHandler(doc, mock.Options{
+		PassthroughTags: []string{"docs"},
+		Next:            registryHandler,
+	}), nil
 }
\ No newline at end of file
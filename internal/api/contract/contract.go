@@ -0,0 +1,239 @@
+// Package contract exercises every registered route against the OpenAPI
+// document generated from the route registry. Importing it from a test gives
+// each route baseline coverage: documented status codes and response bodies
+// that conform to their schemas, for both valid and invalid input.
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/handler"
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/apispec"
+	"{{MODULE_NAME}}/internal/mock"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// Options controls which routes the harness exercises
+type Options struct {
+	// SkipModules lists modules whose routes are not exercised, e.g. "docs"
+	// whose routes serve files rather than JSON contracts
+	SkipModules []string
+	// Skip optionally excludes individual routes
+	Skip func(route types.RouteInfo) bool
+	// Seed makes generated inputs reproducible; zero uses a fixed default
+	Seed int64
+}
+
+// Harness serves the application's handlers and checks exchanges against the spec
+type Harness struct {
+	Doc     *openapi.Document
+	Handler http.Handler
+	Routes  []types.RouteInfo
+}
+
+// New builds a harness for a test, failing it if the harness cannot be built
+func New(tb testing.TB) *Harness {
+	tb.Helper()
+
+	h, err := NewHarness()
+	if err != nil {
+		tb.Fatalf("failed to build contract harness: %v", err)
+	}
+	return h
+}
+
+// NewHarness builds a spec from the route registry and a full HandlerRegistry to serve it
+func NewHarness() (*Harness, error) {
+	registry, err := handler.NewHandlerRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create handler registry: %w", err)
+	}
+
+	jsonSpec, err := apispec.NewGenerator().GenerateJSONSpec()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+	}
+	doc, err := openapi.ParseJSON([]byte(jsonSpec))
+	if err != nil {
+		return nil, err
+	}
+
+	// Admin-only routes are neither documented nor served by the public mux
+	var routes []types.RouteInfo
+	for _, route := range types.GetRegisteredRoutes() {
+		if !route.IsAdmin() {
+			routes = append(routes, route)
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	return &Harness{Doc: doc, Handler: registry.GetServeMux(), Routes: routes}, nil
+}
+
+// Case is a single request generated for a route
+type Case struct {
+	Name        string
+	Method      string
+	Path        string
+	Body        []byte
+	ContentType string
+	// Invalid cases must be rejected with one of the documented client errors
+	Invalid bool
+}
+
+// Run exercises every route through an httptest server, failing the test for
+// undocumented status codes, responses that violate their schema, and invalid
+// input that is not rejected with a 400 or 422
+func (h *Harness) Run(t *testing.T, opts Options) {
+	t.Helper()
+
+	server := httptest.NewServer(h.Handler)
+	defer server.Close()
+
+	for _, route := range h.Routes {
+		if skipRoute(route, opts) {
+			continue
+		}
+
+		route := route
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			for _, c := range h.Cases(route, opts.Seed) {
+				t.Run(c.Name, func(t *testing.T) {
+					h.runCase(t, server, route, c)
+				})
+			}
+		})
+	}
+}
+
+// Run is shorthand for New(t).Run(t, opts)
+func Run(t *testing.T, opts Options) {
+	t.Helper()
+	New(t).Run(t, opts)
+}
+
+// Cases returns the valid and invalid requests generated for a route
+func (h *Harness) Cases(route types.RouteInfo, seed int64) []Case {
+	if seed == 0 {
+		seed = 1
+	}
+	match := h.Doc.FindOperation(route.Method, route.Path)
+	if match == nil || match.Operation == nil {
+		return nil
+	}
+
+	path := h.ConcretePath(match, seed)
+	rb := match.Operation.RequestBody
+	if rb == nil {
+		return []Case{{Name: "valid", Method: route.Method, Path: path}}
+	}
+
+	content, ok := rb.Content["application/json"]
+	if !ok || content.Schema == nil {
+		return []Case{{Name: "valid", Method: route.Method, Path: path}}
+	}
+
+	valid := mock.NewSynthesizer(h.Doc, seed).Value(content.Schema)
+	cases := []Case{{Name: "valid", Method: route.Method, Path: path, Body: mustJSON(valid), ContentType: "application/json"}}
+	for name, body := range InvalidBodies(h.Doc, content.Schema, valid) {
+		cases = append(cases, Case{Name: name, Method: route.Method, Path: path, Body: body, ContentType: "application/json", Invalid: true})
+	}
+	sort.Slice(cases[1:], func(i, j int) bool { return cases[i+1].Name < cases[j+1].Name })
+	return cases
+}
+
+// ConcretePath fills templated path segments with synthesized parameter values
+func (h *Harness) ConcretePath(match *openapi.Match, seed int64) string {
+	params := make(map[string]*openapi.Schema)
+	for _, p := range openapi.MergeParameters(match.PathItem.Parameters, match.Operation.Parameters) {
+		if p.In == "path" {
+			params[p.Name] = p.Schema
+		}
+	}
+
+	synth := mock.NewSynthesizer(h.Doc, seed)
+	segments := strings.Split(match.Template, "/")
+	for i, seg := range segments {
+		if !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") {
+			continue
+		}
+		value := interface{}("1")
+		if schema := params[strings.TrimSuffix(seg[1:len(seg)-1], "...")]; schema != nil {
+			value = synth.Value(schema)
+		}
+		segments[i] = fmt.Sprint(value)
+	}
+	return strings.Join(segments, "/")
+}
+
+// Check validates a response against the documented responses of the
+// operation serving method and path
+func (h *Harness) Check(method, path string, status int, header http.Header, body []byte) []openapi.ValidationError {
+	match := h.Doc.FindOperation(method, path)
+	if match == nil || match.Operation == nil {
+		return []openapi.ValidationError{{Message: fmt.Sprintf("%s %s is not documented", method, path)}}
+	}
+	return h.Doc.ValidateResponse(match.Operation, status, header, body)
+}
+
+// runCase sends one request and asserts the response honours the contract
+func (h *Harness) runCase(t *testing.T, server *httptest.Server, route types.RouteInfo, c Case) {
+	req, err := http.NewRequest(c.Method, server.URL+c.Path, bytes.NewReader(c.Body))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	if c.ContentType != "" {
+		req.Header.Set("Content-Type", c.ContentType)
+	}
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+
+	if c.Invalid && resp.StatusCode != http.StatusBadRequest && resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("%s %s accepted invalid input (%s) with status %d", c.Method, route.Path, c.Name, resp.StatusCode)
+	}
+	for _, violation := range h.Check(c.Method, c.Path, resp.StatusCode, resp.Header, body) {
+		t.Errorf("%s %s returned %d violating the contract at %s", c.Method, route.Path, resp.StatusCode, violation.Error())
+	}
+}
+
+// skipRoute reports whether opts exclude route
+func skipRoute(route types.RouteInfo, opts Options) bool {
+	for _, module := range opts.SkipModules {
+		if route.Module == module {
+			return true
+		}
+	}
+	return opts.Skip != nil && opts.Skip(route)
+}
+
+// mustJSON encodes a synthesized value, which is always JSON-safe
+func mustJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,172 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegisteredRoutes gives every registered route baseline contract coverage.
+// Documentation routes serve files and HTML rather than JSON contracts.
+func TestRegisteredRoutes(t *testing.T) {
+	Run(t, Options{SkipModules: []string{"docs"}})
+}
+
+// TestRegisteredExamples keeps every documented route example in sync with its handler
+func TestRegisteredExamples(t *testing.T) {
+	CheckExamples(t, Options{SkipModules: []string{"docs"}})
+}
+
+func TestInvalidBodies(t *testing.T) {
+	doc, err := openapi.ParseJSON([]byte(`{
+	  "components": {"schemas": {"Item": {
+	    "type": "object",
+	    "required": ["name"],
+	    "properties": {"name": {"type": "string"}, "count": {"type": "integer"}}
+	  }}}
+	}`))
+	require.NoError(t, err)
+
+	valid := map[string]interface{}{"name": "widget", "count": float64(2)}
+	bodies := InvalidBodies(doc, &openapi.Schema{Ref: "#/components/schemas/Item"}, valid)
+
+	assert.Contains(t, bodies, "malformed JSON")
+	assert.Contains(t, bodies, "wrong type")
+	assert.Contains(t, bodies, "missing name")
+	assert.NotContains(t, bodies, "missing count")
+	assert.JSONEq(t, `{"name": "widget", "count": "not a number"}`, string(bodies["wrong type for count"]))
+
+	schema := &openapi.Schema{Ref: "#/components/schemas/Item"}
+	for name, body := range bodies {
+		var value interface{}
+		if json.Unmarshal(body, &value) != nil {
+			continue
+		}
+		assert.NotEmpty(t, doc.Validate(schema, value), name)
+	}
+}
+
+type echoRequest struct {
+	Name  string `json:"name"`
+	Count int    `json:"count,omitempty"`
+}
+
+// echoHandler accepts an echoRequest, rejecting bodies that don't decode strictly
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	var req echoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		types.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" {
+		types.WriteError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(req)
+}
+
+func TestHarness_RequestBodies(t *testing.T) {
+	saved := types.GetRegisteredRoutes()
+	defer types.UpdateRouteRegistry(saved)
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "POST",
+		Path:         "/contract-test/echo",
+		Handler:      echoHandler,
+		RequestType:  reflect.TypeOf(echoRequest{}),
+		ResponseType: reflect.TypeOf(echoRequest{}),
+		Module:       "contract-test",
+	})
+
+	h := New(t)
+	route := types.RouteInfo{Method: "POST", Path: "/contract-test/echo"}
+	cases := h.Cases(route, 0)
+	require.NotEmpty(t, cases)
+	assert.Equal(t, "valid", cases[0].Name)
+	assert.False(t, cases[0].Invalid)
+	assert.True(t, cases[1].Invalid)
+
+	h.Run(t, Options{Skip: func(r types.RouteInfo) bool { return r.Module != "contract-test" }})
+}
+
+func TestHarness_WildcardRoutes(t *testing.T) {
+	saved := types.GetRegisteredRoutes()
+	defer types.UpdateRouteRegistry(saved)
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:  "GET",
+		Path:    "/contract-test/files/{path...}",
+		Handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) },
+		Module:  "contract-test",
+	})
+
+	h := New(t)
+	cases := h.Cases(types.RouteInfo{Method: "GET", Path: "/contract-test/files/{path...}"}, 0)
+	require.Len(t, cases, 1)
+	assert.NotContains(t, cases[0].Path, "{")
+
+	match := h.Doc.FindOperation("GET", cases[0].Path)
+	require.NotNil(t, match)
+	assert.Equal(t, "/contract-test/files/{path...}", match.Template)
+}
+
+func TestCheckExamples(t *testing.T) {
+	registerEchoRoute(t)
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "PUT",
+		Path:         "/contract-test/echo/{id}",
+		Handler:      echoHandler,
+		RequestType:  reflect.TypeOf(echoRequest{}),
+		ResponseType: reflect.TypeOf(echoRequest{}),
+		Module:       "contract-test",
+		RequestExamples: []types.Example{
+			{Name: "minimal", Value: echoRequest{Name: "widget"}},
+			{Name: "counted", Summary: "With a count", Value: echoRequest{Name: "widget", Count: 2}},
+		},
+		ResponseExamples: []types.Example{{Name: "echoed", Value: echoRequest{Name: "widget"}}},
+	})
+
+	h := New(t)
+	h.CheckExamples(t, Options{Skip: func(r types.RouteInfo) bool { return r.Module != "contract-test" }})
+}
+
+func TestExampleViolations(t *testing.T) {
+	registerEchoRoute(t)
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "PUT",
+		Path:         "/contract-test/echo/{id}",
+		Handler:      echoHandler,
+		RequestType:  reflect.TypeOf(echoRequest{}),
+		ResponseType: reflect.TypeOf(echoRequest{}),
+		Module:       "contract-test",
+		RequestExamples: []types.Example{
+			{Name: "rejected", Value: echoRequest{}},
+			{Name: "mistyped", Value: map[string]interface{}{"name": 7}},
+		},
+		ResponseExamples: []types.Example{{Name: "stale", Value: map[string]interface{}{"name": true}}},
+	})
+
+	h := New(t)
+	route := h.Routes[0]
+	for _, r := range h.Routes {
+		if r.Method == "PUT" && r.Path == "/contract-test/echo/{id}" {
+			route = r
+		}
+	}
+	violations := strings.Join(h.ExampleViolations(route, 0), "\n")
+	assert.Contains(t, violations, `request example "rejected" was rejected with status 422`)
+	assert.Contains(t, violations, `request example "mistyped" violates the schema`)
+	assert.Contains(t, violations, `response example "stale" violates the schema`)
+
+	// Examples on routes without a documented body are reported too
+	get := types.RouteInfo{Method: "GET", Path: "/health", RequestExamples: []types.Example{{Name: "x"}}}
+	assert.Contains(t, h.ExampleViolations(get, 0), "request examples are set but no JSON request body is documented")
+}
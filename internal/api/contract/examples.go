@@ -0,0 +1,136 @@
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// CheckExamples fails the test for documented examples that drift from their
+// route: examples that violate the schema, and request examples the handler
+// rejects
+func (h *Harness) CheckExamples(t *testing.T, opts Options) {
+	t.Helper()
+
+	for _, route := range h.Routes {
+		if skipRoute(route, opts) || (len(route.RequestExamples) == 0 && len(route.ResponseExamples) == 0) {
+			continue
+		}
+
+		route := route
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			for _, violation := range h.ExampleViolations(route, opts.Seed) {
+				t.Error(violation)
+			}
+		})
+	}
+}
+
+// CheckExamples is shorthand for New(t).CheckExamples(t, opts)
+func CheckExamples(t *testing.T, opts Options) {
+	t.Helper()
+	New(t).CheckExamples(t, opts)
+}
+
+// ExampleViolations validates the examples documented for a route. Request
+// examples are also sent through the handler, which must answer with a
+// documented status below 400.
+func (h *Harness) ExampleViolations(route types.RouteInfo, seed int64) []string {
+	if seed == 0 {
+		seed = 1
+	}
+	match := h.Doc.FindOperation(route.Method, route.Path)
+	if match == nil || match.Operation == nil {
+		return []string{fmt.Sprintf("%s %s is not documented", route.Method, route.Path)}
+	}
+	op := match.Operation
+
+	var violations []string
+	if len(route.RequestExamples) > 0 {
+		content, ok := requestContent(op)
+		if !ok {
+			violations = append(violations, "request examples are set but no JSON request body is documented")
+		}
+		for _, name := range sortedExampleNames(content.Examples) {
+			value := content.Examples[name].Value
+			for _, err := range h.Doc.Validate(content.Schema, value) {
+				violations = append(violations, fmt.Sprintf("request example %q violates the schema at %s", name, err.Error()))
+			}
+			violations = append(violations, h.sendExample(route.Method, match, name, value, seed)...)
+		}
+	}
+
+	if len(route.ResponseExamples) > 0 {
+		content, ok := successContent(op)
+		if !ok {
+			violations = append(violations, "response examples are set but no JSON success response is documented")
+		}
+		for _, name := range sortedExampleNames(content.Examples) {
+			for _, err := range h.Doc.Validate(content.Schema, content.Examples[name].Value) {
+				violations = append(violations, fmt.Sprintf("response example %q violates the schema at %s", name, err.Error()))
+			}
+		}
+	}
+	return violations
+}
+
+// sendExample serves a request example and checks the handler accepts it
+func (h *Harness) sendExample(method string, match *openapi.Match, name string, value interface{}, seed int64) []string {
+	path := h.ConcretePath(match, seed)
+	req := httptest.NewRequest(method, path, bytes.NewReader(mustJSON(value)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.Handler.ServeHTTP(rec, req)
+
+	var violations []string
+	if rec.Code >= http.StatusBadRequest {
+		violations = append(violations, fmt.Sprintf("request example %q was rejected with status %d: %s", name, rec.Code, strings.TrimSpace(rec.Body.String())))
+	}
+	for _, err := range h.Check(req.Method, path, rec.Code, rec.Header(), rec.Body.Bytes()) {
+		violations = append(violations, fmt.Sprintf("request example %q returned %d violating the contract at %s", name, rec.Code, err.Error()))
+	}
+	return violations
+}
+
+// requestContent returns the documented JSON request body
+func requestContent(op *openapi.Operation) (openapi.MediaType, bool) {
+	if op.RequestBody == nil {
+		return openapi.MediaType{}, false
+	}
+	content, ok := op.RequestBody.Content["application/json"]
+	return content, ok && content.Schema != nil
+}
+
+// successContent returns the JSON body of the lowest documented 2xx response
+func successContent(op *openapi.Operation) (openapi.MediaType, bool) {
+	var codes []string
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if content, ok := op.Responses[code].Content["application/json"]; ok && content.Schema != nil {
+			return content, true
+		}
+	}
+	return openapi.MediaType{}, false
+}
+
+// sortedExampleNames returns example names in a stable order
+func sortedExampleNames(examples map[string]openapi.Example) []string {
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
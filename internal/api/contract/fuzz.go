@@ -0,0 +1,171 @@
+package contract
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/mock"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// FuzzInput is one entry of a route's fuzz corpus. When Body is empty the
+// body is synthesized from the request schema using Seed and then altered by
+// the schema-guided mutation picked by Mutation.
+type FuzzInput struct {
+	Seed      int64
+	Mutation  uint16
+	Body      []byte
+	Query     string
+	PathValue string
+}
+
+// Fuzz is shorthand for New(f).Fuzz(f, method, path)
+func Fuzz(f *testing.F, method, path string) {
+	f.Helper()
+	New(f).Fuzz(f, method, path)
+}
+
+// Fuzz runs a native Go fuzz target for a route. Inputs are sent through the
+// real handler chain and the target fails on panics, 5xx responses and
+// responses that violate the documented schema.
+func (h *Harness) Fuzz(f *testing.F, method, path string) {
+	f.Helper()
+
+	route := types.RouteInfo{Method: method, Path: path}
+	match := h.Doc.FindOperation(method, path)
+	if match == nil || match.Operation == nil {
+		f.Fatalf("%s %s is not documented", method, path)
+	}
+
+	for _, in := range h.SeedCorpus(route) {
+		f.Add(in.Seed, in.Mutation, in.Body, in.Query, in.PathValue)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, mutation uint16, body []byte, query string, pathValue string) {
+		in := FuzzInput{Seed: seed, Mutation: mutation, Body: body, Query: query, PathValue: pathValue}
+		req, err := h.fuzzRequest(method, match, in)
+		if err != nil {
+			t.Skip(err)
+		}
+
+		w := httptest.NewRecorder()
+		h.Handler.ServeHTTP(w, req)
+
+		if w.Code >= http.StatusInternalServerError {
+			t.Errorf("%s %s returned %d: %s", method, path, w.Code, w.Body.String())
+		}
+		for _, violation := range h.Doc.ValidateResponse(match.Operation, w.Code, w.Header(), w.Body.Bytes()) {
+			t.Errorf("%s %s returned %d violating the contract at %s", method, path, w.Code, violation.Error())
+		}
+	})
+}
+
+// SeedCorpus returns the initial fuzz inputs for a route: its documented
+// request examples, the valid and invalid cases generated by the harness and
+// one schema-guided entry per mutation
+func (h *Harness) SeedCorpus(route types.RouteInfo) []FuzzInput {
+	var inputs []FuzzInput
+	if match := h.Doc.FindOperation(route.Method, route.Path); match != nil && match.Operation != nil {
+		if content, ok := requestContent(match.Operation); ok {
+			for _, name := range sortedExampleNames(content.Examples) {
+				inputs = append(inputs, FuzzInput{Seed: 1, Body: mustJSON(content.Examples[name].Value)})
+			}
+		}
+	}
+	for _, c := range h.Cases(route, 1) {
+		if len(c.Body) > 0 {
+			inputs = append(inputs, FuzzInput{Seed: 1, Body: c.Body})
+		}
+	}
+	for m := uint16(0); m < mutationCount; m++ {
+		inputs = append(inputs, FuzzInput{Seed: 1, Mutation: m})
+	}
+	return inputs
+}
+
+// fuzzRequest builds the HTTP request for a fuzz input
+func (h *Harness) fuzzRequest(method string, match *openapi.Match, in FuzzInput) (*http.Request, error) {
+	path := h.ConcretePath(match, in.Seed)
+	if in.PathValue != "" {
+		path = substitutePathValue(match.Template, url.PathEscape(in.PathValue))
+	}
+	target := path
+	if in.Query != "" {
+		target += "?" + in.Query
+	}
+
+	body := in.Body
+	if len(body) == 0 {
+		body = h.guidedBody(match.Operation, in)
+	}
+
+	req, err := http.NewRequest(method, "http://contract.test"+target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unusable fuzz input: %w", err)
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// guidedBody synthesizes a valid body from the request schema and mutates it
+func (h *Harness) guidedBody(op *openapi.Operation, in FuzzInput) []byte {
+	if op.RequestBody == nil {
+		return nil
+	}
+	content, ok := op.RequestBody.Content["application/json"]
+	if !ok || content.Schema == nil {
+		return nil
+	}
+
+	valid := mock.NewSynthesizer(h.Doc, in.Seed).Value(content.Schema)
+	mutated := Mutate(h.Doc, content.Schema, valid, in.Mutation, rand.New(rand.NewSource(in.Seed)))
+	data, err := json.Marshal(mutated)
+	if err != nil {
+		return mustJSON(valid)
+	}
+	return data
+}
+
+// MarshalCorpus encodes the input in the native Go fuzz corpus file format
+// so it can be checked in under testdata/fuzz/<FuzzTarget>/
+func (in FuzzInput) MarshalCorpus() []byte {
+	return []byte(fmt.Sprintf("go test fuzz v1\nint64(%d)\nuint16(%d)\n[]byte(%s)\nstring(%s)\nstring(%s)\n",
+		in.Seed, in.Mutation, strconv.Quote(string(in.Body)), strconv.Quote(in.Query), strconv.Quote(in.PathValue)))
+}
+
+// CorpusName names the corpus file of the input after its content, so
+// regenerating a corpus is idempotent
+func (in FuzzInput) CorpusName() string {
+	sum := sha256.Sum256(in.MarshalCorpus())
+	return "seed-" + hex.EncodeToString(sum[:8])
+}
+
+// substitutePathValue puts value into every templated segment, or appends it
+// to subtree templates that have none
+func substitutePathValue(template, value string) string {
+	segments := strings.Split(template, "/")
+	replaced := false
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			segments[i] = value
+			replaced = true
+		}
+	}
+	if !replaced && strings.HasSuffix(template, "/") {
+		return template + value
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,86 @@
+package contract
+
+import (
+	"flag"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateCorpus rewrites the committed seed corpus of FuzzEchoRoute
+var updateCorpus = flag.Bool("update-corpus", false, "rewrite testdata/fuzz/FuzzEchoRoute")
+
+// registerEchoRoute adds the echo route for the duration of a test
+func registerEchoRoute(tb testing.TB) {
+	saved := types.GetRegisteredRoutes()
+	tb.Cleanup(func() { types.UpdateRouteRegistry(saved) })
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "POST",
+		Path:         "/contract-test/echo",
+		Handler:      echoHandler,
+		RequestType:  reflect.TypeOf(echoRequest{}),
+		ResponseType: reflect.TypeOf(echoRequest{}),
+		Module:       "contract-test",
+		RequestExamples: []types.Example{
+			{Name: "counted", Value: echoRequest{Name: "widget", Count: 2}},
+		},
+	})
+}
+
+func FuzzEchoRoute(f *testing.F) {
+	registerEchoRoute(f)
+	Fuzz(f, "POST", "/contract-test/echo")
+}
+
+func TestSeedCorpus_Committed(t *testing.T) {
+	registerEchoRoute(t)
+	h := New(t)
+
+	dir := filepath.Join("testdata", "fuzz", "FuzzEchoRoute")
+	inputs := h.SeedCorpus(types.RouteInfo{Method: "POST", Path: "/contract-test/echo"})
+	assert.Contains(t, inputs, FuzzInput{Seed: 1, Body: []byte(`{"count":2,"name":"widget"}`)}, "documented example")
+	for _, in := range inputs {
+		if *updateCorpus {
+			require.NoError(t, os.MkdirAll(dir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, in.CorpusName()), in.MarshalCorpus(), 0644))
+		}
+		data, err := os.ReadFile(filepath.Join(dir, in.CorpusName()))
+		if assert.NoError(t, err) {
+			assert.Equal(t, string(in.MarshalCorpus()), string(data))
+		}
+	}
+}
+
+func TestSeedCorpus_MarshalCorpus(t *testing.T) {
+	registerEchoRoute(t)
+	h := New(t)
+
+	inputs := h.SeedCorpus(types.RouteInfo{Method: "POST", Path: "/contract-test/echo"})
+	require.NotEmpty(t, inputs)
+
+	// Corpus entries use the native go test fuzz v1 format
+	data := FuzzInput{Seed: 3, Mutation: 2, Body: []byte(`{"name":"a\n"}`), Query: "q=1"}.MarshalCorpus()
+	assert.Equal(t, "go test fuzz v1\nint64(3)\nuint16(2)\n[]byte(\"{\\\"name\\\":\\\"a\\\\n\\\"}\")\nstring(\"q=1\")\nstring(\"\")\n", string(data))
+}
+
+func TestMutate(t *testing.T) {
+	registerEchoRoute(t)
+	h := New(t)
+	schema := h.Doc.Paths["/contract-test/echo"].Post.RequestBody.Content["application/json"].Schema
+
+	valid := map[string]interface{}{"name": "widget", "count": float64(1)}
+	assert.Equal(t, valid, Mutate(h.Doc, schema, valid, 0, rand.New(rand.NewSource(1))))
+
+	for m := uint16(1); m < mutationCount; m++ {
+		mutated := Mutate(h.Doc, schema, valid, m, rand.New(rand.NewSource(int64(m))))
+		assert.NotEqual(t, valid, mutated, "mutation %d", m)
+	}
+	assert.Equal(t, map[string]interface{}{"name": "widget", "count": float64(1)}, valid, "original must not be modified")
+}
@@ -0,0 +1,78 @@
+package contract
+
+import (
+	"sort"
+
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// InvalidBodies derives request bodies that violate schema from a valid
+// value: malformed JSON, the wrong top-level type, each required property
+// removed, and each property replaced with a value of the wrong type
+func InvalidBodies(doc *openapi.Document, schema *openapi.Schema, valid interface{}) map[string][]byte {
+	bodies := map[string][]byte{
+		"malformed JSON": []byte(`{"unterminated": `),
+	}
+
+	resolved := doc.ResolveSchema(schema)
+	if resolved == nil {
+		return bodies
+	}
+	bodies["wrong type"] = mustJSON(wrongTypeValue(resolved.Type))
+
+	obj, ok := valid.(map[string]interface{})
+	if !ok || resolved.Type != "object" {
+		return bodies
+	}
+
+	for _, name := range resolved.Required {
+		if _, present := obj[name]; !present {
+			continue
+		}
+		bodies["missing "+name] = mustJSON(withoutKey(obj, name))
+	}
+
+	names := make([]string, 0, len(resolved.Properties))
+	for name := range resolved.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := doc.ResolveSchema(resolved.Properties[name])
+		if prop == nil || prop.Type == "" {
+			continue
+		}
+		mutated := withoutKey(obj, name)
+		mutated[name] = wrongTypeValue(prop.Type)
+		bodies["wrong type for "+name] = mustJSON(mutated)
+	}
+
+	return bodies
+}
+
+// wrongTypeValue returns a JSON value that is not of the given schema type
+func wrongTypeValue(schemaType string) interface{} {
+	switch schemaType {
+	case "string":
+		return 12345
+	case "integer", "number":
+		return "not a number"
+	case "boolean":
+		return "not a boolean"
+	case "array":
+		return map[string]interface{}{"not": "an array"}
+	default:
+		return []interface{}{"not", "an", "object"}
+	}
+}
+
+// withoutKey returns a shallow copy of obj without key
+func withoutKey(obj map[string]interface{}, key string) map[string]interface{} {
+	out := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if k != key {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package contract
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// mutationCount is the number of distinct schema-guided mutations
+const mutationCount = 8
+
+// Mutate applies a schema-guided mutation to a copy of a valid value. The
+// selector picks both the mutation and, via rng, the node it applies to, so
+// the fuzzer explores edge cases of each documented field rather than only
+// random bytes.
+func Mutate(doc *openapi.Document, schema *openapi.Schema, valid interface{}, selector uint16, rng *rand.Rand) interface{} {
+	value := deepCopy(valid)
+	if selector == 0 {
+		return value
+	}
+
+	op := int(selector) % mutationCount
+	return mutateNode(doc, doc.ResolveSchema(schema), value, op, rng, 0)
+}
+
+// mutateNode descends into a random child or mutates the current node
+func mutateNode(doc *openapi.Document, schema *openapi.Schema, value interface{}, op int, rng *rand.Rand, depth int) interface{} {
+	if schema != nil && depth < 8 && rng.Intn(3) > 0 {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if keys := sortedObjectKeys(v); len(keys) > 0 {
+				key := keys[rng.Intn(len(keys))]
+				if op == 0 {
+					delete(v, key)
+					return v
+				}
+				v[key] = mutateNode(doc, doc.ResolveSchema(schema.Properties[key]), v[key], op, rng, depth+1)
+				return v
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				i := rng.Intn(len(v))
+				v[i] = mutateNode(doc, doc.ResolveSchema(schema.Items), v[i], op, rng, depth+1)
+				return v
+			}
+		}
+	}
+
+	schemaType := ""
+	if schema != nil {
+		schemaType = schema.Type
+	}
+
+	switch op {
+	case 1:
+		return nil
+	case 2:
+		return wrongTypeValue(schemaType)
+	case 3:
+		return ""
+	case 4:
+		return strings.Repeat("é\u0000<>\"'", 1+rng.Intn(2048))
+	case 5:
+		extremes := []interface{}{math.MaxFloat64, -math.MaxFloat64, float64(math.MaxInt64), float64(math.MinInt64), -1, 0, 1e-300}
+		return extremes[rng.Intn(len(extremes))]
+	case 6:
+		if obj, ok := value.(map[string]interface{}); ok {
+			obj["unexpected_property"] = "surprise"
+			return obj
+		}
+		return map[string]interface{}{"unexpected_property": value}
+	case 7:
+		nested := value
+		for i := 0; i < 64; i++ {
+			nested = []interface{}{nested}
+		}
+		return nested
+	}
+	return value
+}
+
+// deepCopy copies decoded JSON values so mutations never alias the original
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = deepCopy(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = deepCopy(item)
+		}
+		return out
+	}
+	return value
+}
+
+// sortedObjectKeys returns object keys in sorted order so mutations are reproducible
+func sortedObjectKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,13 @@
+// Reloads the documentation page when the OpenAPI generator's -watch mode
+// reports a new spec. Only included when docs.live_reload is enabled.
+(function() {
+    const script = document.currentScript;
+    const url = script && script.dataset.eventsUrl;
+    if (!url || !window.EventSource) {
+        return;
+    }
+    const source = new EventSource(url);
+    source.addEventListener('reload', function() {
+        window.location.reload();
+    });
+})();
@@ -0,0 +1,32 @@
+// Initializes Swagger UI from data attributes so the page needs no inline script.
+// When split specs are available the UI offers a selector listing all of them.
+window.onload = function() {
+    const container = document.getElementById('swagger-ui');
+    const options = {
+        dom_id: '#swagger-ui',
+        deepLinking: true,
+        presets: [
+            SwaggerUIBundle.presets.apis,
+            SwaggerUIStandalonePreset
+        ],
+        plugins: [
+            SwaggerUIBundle.plugins.DownloadUrl
+        ],
+        layout: "StandaloneLayout"
+    };
+
+    const start = function(urls) {
+        if (urls && urls.length > 1) {
+            options.urls = urls;
+            options["urls.primaryName"] = urls[0].name;
+        } else {
+            options.url = container.dataset.specUrl;
+        }
+        window.ui = SwaggerUIBundle(options);
+    };
+
+    fetch(container.dataset.specsIndexUrl)
+        .then(function(response) { return response.ok ? response.json() : []; })
+        .then(start)
+        .catch(function() { start([]); });
+};
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http/pprof"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+func init() {
+	// Profiling endpoints are only served on the admin listener
+	types.RegisterAdminModule("debug")
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:  "GET",
+		Path:    "/debug/pprof/",
+		Handler: pprof.Index,
+		Module:  "debug",
+		Summary: "Index of runtime profiles; /debug/pprof/{profile} serves each one",
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:  "GET",
+		Path:    "/debug/pprof/cmdline",
+		Handler: pprof.Cmdline,
+		Module:  "debug",
+		Summary: "Command line of the running process",
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/debug/pprof/profile",
+		Handler:      pprof.Profile,
+		Module:       "debug",
+		Summary:      "CPU profile; the seconds query parameter sets its duration",
+		WriteTimeout: types.NoWriteTimeout,
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:  "GET",
+		Path:    "/debug/pprof/symbol",
+		Handler: pprof.Symbol,
+		Module:  "debug",
+		Summary: "Looks up program counters",
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:  "POST",
+		Path:    "/debug/pprof/symbol",
+		Handler: pprof.Symbol,
+		Module:  "debug",
+		Summary: "Looks up program counters listed in the request body",
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/debug/pprof/trace",
+		Handler:      pprof.Trace,
+		Module:       "debug",
+		Summary:      "Execution trace; the seconds query parameter sets its duration",
+		WriteTimeout: types.NoWriteTimeout,
+	})
+}
@@ -1,64 +1,66 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
+	"html/template"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"{{MODULE_NAME}}/internal/config"
 	"{{MODULE_NAME}}/internal/logging"
 )
 
-// SwaggerUIHandler serves the Swagger UI interface
-func SwaggerUIHandler(w http.ResponseWriter, r *http.Request) {
-	html := `<!DOCTYPE html>
+// swaggerUITemplate renders the Swagger UI page using only embedded assets.
+// Initialization lives in swagger-initializer.js so no inline script is needed.
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
 <html lang="en">
 <head>
     <meta charset="UTF-8">
     <title>API Documentation</title>
-    <link rel="stylesheet" type="text/css" href="https://unpkg.com/swagger-ui-dist@5.9.0/swagger-ui.css" />
-    <style>
-        html {
-            box-sizing: border-box;
-            overflow: -moz-scrollbars-vertical;
-            overflow-y: scroll;
-        }
-        *, *:before, *:after {
-            box-sizing: inherit;
-        }
-        body {
-            margin:0;
-            background: #fafafa;
-        }
-    </style>
+    <link rel="stylesheet" type="text/css" href="{{.AssetBase}}/swagger-ui/swagger-ui.css?v={{.Version}}" />
+    <link rel="stylesheet" type="text/css" href="{{.AssetBase}}/docs/docs.css?v={{.Version}}" />
 </head>
 <body>
-    <div id="swagger-ui"></div>
-    <script src="https://unpkg.com/swagger-ui-dist@5.9.0/swagger-ui-bundle.js"></script>
-    <script src="https://unpkg.com/swagger-ui-dist@5.9.0/swagger-ui-standalone-preset.js"></script>
-    <script>
-        window.onload = function() {
-            const ui = SwaggerUIBundle({
-                url: '/api/docs/openapi.json',
-                dom_id: '#swagger-ui',
-                deepLinking: true,
-                presets: [
-                    SwaggerUIBundle.presets.apis,
-                    SwaggerUIStandalonePreset
-                ],
-                plugins: [
-                    SwaggerUIBundle.plugins.DownloadUrl
-                ],
-                layout: "StandaloneLayout"
-            });
-        };
-    </script>
+    <div id="swagger-ui" data-spec-url="{{.SpecURL}}" data-specs-index-url="/api/docs/specs"></div>
+    <script src="{{.AssetBase}}/swagger-ui/swagger-ui-bundle.js?v={{.Version}}"></script>
+    <script src="{{.AssetBase}}/swagger-ui/swagger-ui-standalone-preset.js?v={{.Version}}"></script>
+    <script src="{{.AssetBase}}/docs/swagger-initializer.js?v={{.Version}}"></script>
+    {{if .EventsURL}}<script src="{{.AssetBase}}/docs/live-reload.js?v={{.Version}}" data-events-url="{{.EventsURL}}"></script>{{end}}
 </body>
-</html>`
+</html>`))
 
-	w.Header().Set("Content-Type", "text/html")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(html))
+// redocTemplate renders the ReDoc page using only embedded assets.
+// The standalone bundle initializes the <redoc> element on its own.
+var redocTemplate = template.Must(template.New("redoc").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <title>API Documentation - ReDoc</title>
+    <meta charset="utf-8"/>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <link rel="stylesheet" type="text/css" href="{{.AssetBase}}/docs/docs.css?v={{.Version}}" />
+</head>
+<body>
+    <redoc spec-url="{{.SpecURL}}"></redoc>
+    <script src="{{.AssetBase}}/redoc/redoc.standalone.js?v={{.Version}}"></script>
+    {{if .EventsURL}}<script src="{{.AssetBase}}/docs/live-reload.js?v={{.Version}}" data-events-url="{{.EventsURL}}"></script>{{end}}
+</body>
+</html>`))
+
+// docsPageData holds the values rendered into the documentation pages
+type docsPageData struct {
+	AssetBase string
+	SpecURL   string
+	Version   string
+	EventsURL string // Live reload stream; empty unless docs.live_reload is set
+}
+
+// SwaggerUIHandler serves the Swagger UI interface
+func SwaggerUIHandler(w http.ResponseWriter, r *http.Request) {
+	renderDocsPage(w, swaggerUITemplate)
 }
 
 // OpenAPIJSONHandler serves the OpenAPI specification in JSON format
@@ -114,29 +116,176 @@ func OpenAPIYAMLHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
+// SpecIndexEntry is one spec offered in the docs spec selector
+type SpecIndexEntry struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// splitSpecIndexEntry mirrors the index.json written by the generator's -split mode
+type splitSpecIndexEntry struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	JSON  string `json:"json"`
+	YAML  string `json:"yaml"`
+}
+
+// SpecIndexHandler lists the available specs: the combined document first,
+// followed by any per-module and per-version documents
+func SpecIndexHandler(w http.ResponseWriter, r *http.Request) {
+	index := []SpecIndexEntry{
+		{Name: "All APIs", URL: docsSpecURL()},
+	}
+
+	data, err := os.ReadFile(filepath.Join(splitSpecsDir(), "index.json"))
+	if err == nil {
+		var entries []splitSpecIndexEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			logging.Error("Invalid split spec index: %v", err)
+		}
+		for _, entry := range entries {
+			index = append(index, SpecIndexEntry{
+				Name: entry.Title,
+				URL:  "/api/docs/specs/" + entry.JSON,
+			})
+		}
+	} else if !os.IsNotExist(err) {
+		logging.Error("Failed to read split spec index: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(index); err != nil {
+		logging.Error("Failed to encode spec index: %v", err)
+	}
+}
+
+// SpecFileHandler serves a single split spec file by name
+func SpecFileHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/docs/specs/")
+	if name == "" || name == "index.json" || name != filepath.Base(name) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var contentType string
+	switch filepath.Ext(name) {
+	case ".json":
+		contentType = "application/json"
+	case ".yaml":
+		contentType = "application/x-yaml"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := os.ReadFile(filepath.Join(splitSpecsDir(), name))
+	if err != nil {
+		logging.Debug("Split spec %s not found: %v", name, err)
+		http.Error(w, "OpenAPI specification not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// splitSpecsDir returns the directory holding the generator's split specs
+func splitSpecsDir() string {
+	return filepath.Join("docs", "api", "specs")
+}
+
 // ReDocHandler serves the ReDoc interface as an alternative to Swagger UI
 func ReDocHandler(w http.ResponseWriter, r *http.Request) {
-	html := `<!DOCTYPE html>
-<html>
-<head>
-    <title>API Documentation - ReDoc</title>
-    <meta charset="utf-8"/>
-    <meta name="viewport" content="width=device-width, initial-scale=1">
-    <link href="https://fonts.googleapis.com/css?family=Montserrat:300,400,700|Roboto:300,400,700" rel="stylesheet">
-    <style>
-        body {
-            margin: 0;
-            padding: 0;
-        }
-    </style>
-</head>
-<body>
-    <redoc spec-url='/api/docs/openapi.json'></redoc>
-    <script src="https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js"></script>
-</body>
-</html>`
+	renderDocsPage(w, redocTemplate)
+}
+
+// renderDocsPage writes a documentation page with its security headers
+func renderDocsPage(w http.ResponseWriter, tmpl *template.Template) {
+	data := docsPageData{
+		AssetBase: docsAssetsPath(),
+		SpecURL:   docsSpecURL(),
+		Version:   docsAssetVersion(),
+	}
+	if docsLiveReloadEnabled() && docsEventsPublic() {
+		data.EventsURL = docsEventsPath
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		logging.Error("Failed to render documentation page: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Set("Content-Type", "text/html")
+	w.Header().Set("Content-Security-Policy", docsContentSecurityPolicy(data.SpecURL))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "no-cache")
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(html))
-}
\ No newline at end of file
+	w.Write(buf.Bytes())
+}
+
+// docsContentSecurityPolicy builds the CSP for the documentation pages.
+// Scripts may only come from this origin; styles allow inline use because
+// both Swagger UI and ReDoc inject style attributes at runtime.
+func docsContentSecurityPolicy(specURL string) string {
+	connectSrc := "'self'"
+	if u, err := url.Parse(specURL); err == nil && u.Scheme != "" && u.Host != "" {
+		connectSrc += " " + u.Scheme + "://" + u.Host
+	}
+
+	directives := []string{
+		"default-src 'none'",
+		"script-src 'self'",
+		"style-src 'self' 'unsafe-inline'",
+		"img-src 'self' data:",
+		"font-src 'self' data:",
+		"connect-src " + connectSrc,
+		"worker-src 'self' blob:",
+		"base-uri 'none'",
+		"form-action 'none'",
+		"frame-ancestors 'none'",
+	}
+	return strings.Join(directives, "; ")
+}
+
+// docsBasePath returns the configured base path for the documentation UI
+func docsBasePath() string {
+	base := strings.TrimRight(config.GetString(config.DocsBasePathKey), "/")
+	if base == "" {
+		return "/docs"
+	}
+	if !strings.HasPrefix(base, "/") {
+		base = "/" + base
+	}
+	return base
+}
+
+// docsAssetsPath returns the route prefix under which UI assets are served
+func docsAssetsPath() string {
+	return docsBasePath() + "/assets"
+}
+
+// docsSpecURL returns the URL the documentation UIs load the spec from
+func docsSpecURL() string {
+	if specURL := config.GetString(config.DocsSpecURLKey); specURL != "" {
+		return specURL
+	}
+	return "/api/docs/openapi.json"
+}
+
+// docsEnabled reports whether the documentation routes should be served
+func docsEnabled() bool {
+	return config.GetBool(config.DocsEnabledKey)
+}
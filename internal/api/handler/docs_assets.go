@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// embeddedAssets holds the vendored Swagger UI and ReDoc bundles.
+// Run scripts/vendor-docs-assets.sh to refresh the third-party files.
+//
+//go:embed assets
+var embeddedAssets embed.FS
+
+var (
+	// docsAssets is the filesystem the asset handler serves from
+	docsAssets fs.FS = mustSubFS(embeddedAssets, "assets")
+
+	assetVersion     string
+	assetVersionOnce sync.Once
+)
+
+// mustSubFS returns the subtree of fsys rooted at dir
+func mustSubFS(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// docsAssetVersion returns a short content hash of the embedded assets.
+// It is appended to asset URLs so they can be cached indefinitely.
+func docsAssetVersion() string {
+	assetVersionOnce.Do(func() {
+		h := sha256.New()
+		err := fs.WalkDir(docsAssets, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(docsAssets, path)
+			if err != nil {
+				return err
+			}
+			h.Write([]byte(path))
+			h.Write(data)
+			return nil
+		})
+		if err != nil {
+			logging.Warn("Failed to hash documentation assets: %v", err)
+		}
+		assetVersion = hex.EncodeToString(h.Sum(nil))[:12]
+	})
+	return assetVersion
+}
+
+// DocsAssetsHandler serves the embedded documentation UI assets with
+// long-lived caching headers
+func DocsAssetsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, docsAssetsPath()+"/")
+	if name == "" || name == r.URL.Path || strings.HasSuffix(name, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if _, err := fs.Stat(docsAssets, name); err != nil {
+		logging.Debug("Documentation asset not found: %s", name)
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeFileFS(w, r, docsAssets, name)
+}
@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"reflect"
+
 	"{{MODULE_NAME}}/internal/api/types"
 )
 
 func init() {
+	basePath := docsBasePath()
+
 	// Register Swagger UI endpoint
 	types.RegisterRoute(types.RouteInfo{
 		Method:  "GET",
-		Path:    "/docs",
+		Path:    basePath,
 		Handler: SwaggerUIHandler,
 		Module:  "docs",
 		Summary: "Swagger UI documentation interface",
@@ -23,6 +27,15 @@ func init() {
 		Summary: "ReDoc documentation interface",
 	})
 
+	// Register embedded Swagger UI and ReDoc assets
+	types.RegisterRoute(types.RouteInfo{
+		Method:  "GET",
+		Path:    docsAssetsPath() + "/",
+		Handler: DocsAssetsHandler,
+		Module:  "docs",
+		Summary: "Static assets for the documentation interfaces",
+	})
+
 	// Register OpenAPI JSON endpoint
 	types.RegisterRoute(types.RouteInfo{
 		Method:  "GET",
@@ -41,12 +54,51 @@ func init() {
 		Summary: "OpenAPI specification in YAML format",
 	})
 
-	// Convenience redirect from root docs path
+	// Register index of combined, per-module and per-version specs
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/api/docs/specs",
+		Handler:      SpecIndexHandler,
+		ResponseType: reflect.TypeOf([]SpecIndexEntry{}),
+		Module:       "docs",
+		Summary:      "List of available OpenAPI specifications",
+	})
+
+	// Register split spec files
 	types.RegisterRoute(types.RouteInfo{
 		Method:  "GET",
-		Path:    "/api/docs",
-		Handler: SwaggerUIHandler,
+		Path:    "/api/docs/specs/",
+		Handler: SpecFileHandler,
 		Module:  "docs",
-		Summary: "API documentation (redirects to Swagger UI)",
+		Summary: "Per-module or per-version OpenAPI specification",
+	})
+
+	// Register live reload notifications used by the generator's -watch mode
+	types.RegisterRoute(types.RouteInfo{
+		Method:        "POST",
+		Path:          docsReloadPath,
+		Handler:       DocsReloadHandler,
+		Module:        "docs",
+		Summary:       "Reload open documentation pages (when docs.live_reload is enabled)",
+		SuccessStatus: 204,
 	})
-}
\ No newline at end of file
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         docsEventsPath,
+		Handler:      DocsEventsHandler,
+		Module:       "docs",
+		Summary:      "Documentation reload events (when docs.live_reload is enabled)",
+		WriteTimeout: types.NoWriteTimeout,
+	})
+
+	// Convenience redirect from root docs path
+	if basePath != "/api/docs" {
+		types.RegisterRoute(types.RouteInfo{
+			Method:  "GET",
+			Path:    "/api/docs",
+			Handler: SwaggerUIHandler,
+			Module:  "docs",
+			Summary: "API documentation (redirects to Swagger UI)",
+		})
+	}
+}
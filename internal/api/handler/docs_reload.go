@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"{{MODULE_NAME}}/internal/config"
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+const (
+	// docsReloadPath is called by the generator's -notify flag
+	docsReloadPath = "/api/docs/reload"
+	// docsEventsPath is the Server-Sent Events stream docs pages listen on
+	docsEventsPath = "/api/docs/events"
+)
+
+// reloadBroadcaster fans reload notifications out to open docs pages
+type reloadBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+	closed      chan struct{} // Closed to end every stream
+	closeOnce   sync.Once
+}
+
+// docsReloads notifies the docs pages served by this process
+var docsReloads = newReloadBroadcaster()
+
+// newReloadBroadcaster creates a broadcaster without listeners
+func newReloadBroadcaster() *reloadBroadcaster {
+	return &reloadBroadcaster{subscribers: make(map[chan struct{}]struct{}), closed: make(chan struct{})}
+}
+
+// subscribe registers a listener; call the returned function to remove it
+func (b *reloadBroadcaster) subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
+}
+
+// broadcast wakes every listener and returns how many there were
+func (b *reloadBroadcaster) broadcast() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default: // A reload is already pending for this listener
+		}
+	}
+	return len(b.subscribers)
+}
+
+// close ends every stream, current and future
+func (b *reloadBroadcaster) close() {
+	b.closeOnce.Do(func() { close(b.closed) })
+}
+
+// CloseDocsStreams ends the live reload streams of open docs pages, which
+// would otherwise hold up a graceful shutdown until it times out. Register
+// it with http.Server.RegisterOnShutdown.
+func CloseDocsStreams() {
+	docsReloads.close()
+}
+
+// DocsReloadHandler tells open documentation pages to reload. The
+// generator's -notify flag calls it after rewriting the spec.
+func DocsReloadHandler(w http.ResponseWriter, r *http.Request) {
+	if !docsLiveReloadEnabled() {
+		http.NotFound(w, r)
+		return
+	}
+	logging.Info("OpenAPI specification changed, reloading %d documentation pages", docsReloads.broadcast())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DocsEventsHandler streams a "reload" Server-Sent Event whenever
+// DocsReloadHandler is called
+func DocsEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if !docsLiveReloadEnabled() {
+		http.NotFound(w, r)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	reloads, unsubscribe := docsReloads.subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-docsReloads.closed:
+			return
+		case <-reloads:
+			fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// docsLiveReloadEnabled reports whether the live reload routes are active
+func docsLiveReloadEnabled() bool {
+	return config.GetBool(config.DocsLiveReloadKey)
+}
+
+// docsEventsPublic reports whether docs pages can reach the events stream,
+// which is not the case once UseAdminListener moved it to the admin listener
+func docsEventsPublic() bool {
+	for _, route := range GetRegisteredRoutes() {
+		if route.Path == docsEventsPath {
+			return !route.IsAdmin()
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"{{MODULE_NAME}}/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocsLiveReload_Disabled(t *testing.T) {
+	for _, handler := range []http.HandlerFunc{DocsReloadHandler, DocsEventsHandler} {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	SwaggerUIHandler(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.NotContains(t, w.Body.String(), "live-reload.js")
+}
+
+func TestDocsLiveReload(t *testing.T) {
+	config.SetForTest(config.DocsLiveReloadKey, true)
+	defer config.SetForTest(config.DocsLiveReloadKey, false)
+
+	for _, page := range []http.HandlerFunc{SwaggerUIHandler, ReDocHandler} {
+		w := httptest.NewRecorder()
+		page(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Contains(t, w.Body.String(), `live-reload.js?v=`)
+		assert.Contains(t, w.Body.String(), `data-events-url="/api/docs/events"`)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(DocsEventsHandler))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := bufio.NewReader(resp.Body)
+	line, err := events.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": connected\n", line)
+
+	w := httptest.NewRecorder()
+	DocsReloadHandler(w, httptest.NewRequest(http.MethodPost, "/api/docs/reload", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	var event strings.Builder
+	for !strings.HasSuffix(event.String(), "\n\n") || event.String() == "\n" {
+		line, err := events.ReadString('\n')
+		require.NoError(t, err)
+		event.WriteString(line)
+	}
+	assert.Equal(t, "\nevent: reload\ndata: {}\n\n", event.String())
+}
+
+func TestCloseDocsStreams_UnblocksShutdown(t *testing.T) {
+	config.SetForTest(config.DocsLiveReloadKey, true)
+	defer config.SetForTest(config.DocsLiveReloadKey, false)
+	saved := docsReloads
+	docsReloads = newReloadBroadcaster()
+	defer func() { docsReloads = saved }()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(DocsEventsHandler))
+	server.Config.RegisterOnShutdown(CloseDocsStreams)
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	_, err = bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, server.Config.Shutdown(ctx), "open streams must not hold up shutdown")
+	_, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+}
@@ -2,12 +2,16 @@ package handler
 
 import (
 	"encoding/json"
+	"html/template"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 
+	"{{MODULE_NAME}}/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -61,7 +65,7 @@ func TestOpenAPIJSONHandler(t *testing.T) {
 	originalDir, err := os.Getwd()
 	require.NoError(t, err)
 	defer os.Chdir(originalDir)
-	
+
 	err = os.Chdir(tempDir)
 	require.NoError(t, err)
 
@@ -86,7 +90,7 @@ func TestOpenAPIJSONHandler_FileNotFound(t *testing.T) {
 	originalDir, err := os.Getwd()
 	require.NoError(t, err)
 	defer os.Chdir(originalDir)
-	
+
 	err = os.Chdir(tempDir)
 	require.NoError(t, err)
 
@@ -118,7 +122,7 @@ info:
 	originalDir, err := os.Getwd()
 	require.NoError(t, err)
 	defer os.Chdir(originalDir)
-	
+
 	err = os.Chdir(tempDir)
 	require.NoError(t, err)
 
@@ -131,4 +135,167 @@ info:
 	assert.Equal(t, "application/x-yaml", w.Header().Get("Content-Type"))
 	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
 	assert.Contains(t, w.Body.String(), "openapi: 3.0.3")
-}
\ No newline at end of file
+}
+
+func TestDocsPages_NoExternalAssets(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{
+		"/docs":  SwaggerUIHandler,
+		"/redoc": ReDocHandler,
+	}
+
+	for path, h := range handlers {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			w := httptest.NewRecorder()
+
+			h(w, req)
+
+			body := w.Body.String()
+			assert.NotContains(t, body, "https://")
+			assert.NotContains(t, body, "<script>")
+			assert.Contains(t, body, "/docs/assets/")
+
+			csp := w.Header().Get("Content-Security-Policy")
+			assert.Contains(t, csp, "default-src 'none'")
+			assert.Contains(t, csp, "script-src 'self'")
+		})
+	}
+}
+
+func TestDocsContentSecurityPolicy_ExternalSpec(t *testing.T) {
+	csp := docsContentSecurityPolicy("https://specs.example.com/api/openapi.json")
+	assert.Contains(t, csp, "connect-src 'self' https://specs.example.com")
+
+	csp = docsContentSecurityPolicy("/api/docs/openapi.json")
+	assert.Contains(t, csp, "connect-src 'self';")
+}
+
+func TestDocsAssetsHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/docs/assets/docs/swagger-initializer.js", nil)
+	w := httptest.NewRecorder()
+
+	DocsAssetsHandler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+	assert.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), "SwaggerUIBundle")
+}
+
+func TestDocsPages_ReferencedAssetsEmbedded(t *testing.T) {
+	config.SetForTest(config.DocsLiveReloadKey, true)
+	defer config.SetForTest(config.DocsLiveReloadKey, false)
+
+	// A missing bundle renders a blank page; run scripts/vendor-docs-assets.sh
+	assetRef := regexp.MustCompile(`(?:src|href)="` + docsAssetsPath() + `/([^"?]+)`)
+	for _, tmpl := range []*template.Template{swaggerUITemplate, redocTemplate} {
+		w := httptest.NewRecorder()
+		renderDocsPage(w, tmpl)
+
+		refs := assetRef.FindAllStringSubmatch(w.Body.String(), -1)
+		require.NotEmpty(t, refs, tmpl.Name())
+		for _, ref := range refs {
+			_, err := fs.Stat(docsAssets, ref[1])
+			assert.NoError(t, err, "%s references %s", tmpl.Name(), ref[1])
+		}
+	}
+}
+
+func TestDocsAssetsHandler_NotFound(t *testing.T) {
+	paths := []string{
+		"/docs/assets/missing.js",
+		"/docs/assets/docs/",
+		"/docs/assets/../docs_assets.go",
+	}
+
+	for _, path := range paths {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+
+		DocsAssetsHandler(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
+
+func TestRegisterHandlers_DocsDisabled(t *testing.T) {
+	config.SetForTest(config.DocsEnabledKey, false)
+	defer config.SetForTest(config.DocsEnabledKey, true)
+
+	registry, err := NewHandlerRegistry()
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+	w := httptest.NewRecorder()
+	registry.GetServeMux().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	w = httptest.NewRecorder()
+	registry.GetServeMux().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSpecIndexHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	specsDir := filepath.Join(tempDir, "docs", "api", "specs")
+	require.NoError(t, os.MkdirAll(specsDir, 0755))
+
+	index := `[{"name": "module-health", "title": "Module: health", "json": "module-health.json", "yaml": "module-health.yaml"}]`
+	require.NoError(t, os.WriteFile(filepath.Join(specsDir, "index.json"), []byte(index), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(specsDir, "module-health.json"), []byte(`{"openapi": "3.0.3"}`), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(tempDir))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/docs/specs", nil)
+	w := httptest.NewRecorder()
+
+	SpecIndexHandler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var entries []SpecIndexEntry
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "/api/docs/openapi.json", entries[0].URL)
+	assert.Equal(t, SpecIndexEntry{Name: "Module: health", URL: "/api/docs/specs/module-health.json"}, entries[1])
+
+	req = httptest.NewRequest(http.MethodGet, entries[1].URL, nil)
+	w = httptest.NewRecorder()
+
+	SpecFileHandler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "3.0.3")
+}
+
+func TestSpecIndexHandler_NoSplitSpecs(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(tempDir))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/docs/specs", nil)
+	w := httptest.NewRecorder()
+
+	SpecIndexHandler(w, req)
+
+	var entries []SpecIndexEntry
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	assert.Len(t, entries, 1)
+}
+
+func TestSpecFileHandler_RejectsInvalidNames(t *testing.T) {
+	for _, path := range []string{"/api/docs/specs/", "/api/docs/specs/index.json", "/api/docs/specs/a/b.json", "/api/docs/specs/spec.txt"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+
+		SpecFileHandler(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
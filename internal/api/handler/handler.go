@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"{{MODULE_NAME}}/internal/api/types"
 	"{{MODULE_NAME}}/internal/logging"
@@ -11,6 +15,7 @@ import (
 type HandlerRegistry struct {
 	healthHandler *HealthHandler
 	mux           *http.ServeMux
+	adminMux      *http.ServeMux
 }
 
 // NewHandlerRegistry creates a new handler registry with all handlers initialized
@@ -26,33 +31,135 @@ func NewHandlerRegistry() (*HandlerRegistry, error) {
 	registry := &HandlerRegistry{
 		healthHandler: healthHandler,
 		mux:           http.NewServeMux(),
+		adminMux:      http.NewServeMux(),
 	}
 
 	registry.RegisterHandlers(registry.mux)
+	registry.RegisterAdminHandlers(registry.adminMux)
 	logging.Info("Handler registry initialized successfully with all handlers")
 
 	return registry, nil
 }
 
-// RegisterHandlers registers all application handlers using the RouteInfo registry
+// UseAdminListener moves the operational routes to the admin listener: the
+// health module, including /version, unless publicProbes is set, and the docs
+// live reload routes. Call it before NewHandlerRegistry when an admin
+// listener is configured.
+func UseAdminListener(publicProbes bool) {
+	if !publicProbes {
+		types.RegisterAdminModule("health")
+	}
+
+	routes := GetRegisteredRoutes()
+	for i, route := range routes {
+		if route.Path == docsReloadPath || route.Path == docsEventsPath {
+			routes[i].Admin = true
+		}
+	}
+	types.UpdateRouteRegistry(routes)
+}
+
+// RegisterHandlers registers all public application handlers using the RouteInfo registry
 func (hr *HandlerRegistry) RegisterHandlers(mux *http.ServeMux) {
 	logging.Info("Registering all application handlers from RouteInfo registry")
+	hr.registerRoutes(mux, false)
+}
 
+// RegisterAdminHandlers registers the admin-only handlers using the RouteInfo registry
+func (hr *HandlerRegistry) RegisterAdminHandlers(mux *http.ServeMux) {
+	logging.Info("Registering admin handlers from RouteInfo registry")
+	hr.registerRoutes(mux, true)
+}
+
+// registerRoutes registers the public or the admin-only routes with mux
+func (hr *HandlerRegistry) registerRoutes(mux *http.ServeMux, admin bool) {
 	// Update RouteInfo registry with actual handler functions
 	hr.updateRouteHandlers()
 
-	// Register all routes from the central registry
+	// Register all routes from the central registry, grouping routes that
+	// share a path so each path is registered with the mux exactly once
 	routes := GetRegisteredRoutes()
+	var paths []string
+	byPath := make(map[string][]types.RouteInfo)
+	registered := 0
 	for _, route := range routes {
+		if route.IsAdmin() != admin {
+			continue
+		}
+		if route.Module == "docs" && !docsEnabled() {
+			logging.Debug("Skipping route %s %s - documentation is disabled", route.Method, route.Path)
+			continue
+		}
 		if route.Handler != nil {
-			mux.HandleFunc(route.Path, route.Handler)
+			if route.WriteTimeout != 0 {
+				route.Handler = withWriteTimeout(route.Handler, route.WriteTimeout)
+			}
+			if _, seen := byPath[route.Path]; !seen {
+				paths = append(paths, route.Path)
+			}
+			byPath[route.Path] = append(byPath[route.Path], route)
+			registered++
 			logging.Debug("Registered %s %s from %s module", route.Method, route.Path, route.Module)
 		} else {
 			logging.Warn("Skipping route %s %s - handler is nil", route.Method, route.Path)
 		}
 	}
 
-	logging.Info("Successfully registered %d handlers from RouteInfo registry", len(routes))
+	for _, path := range paths {
+		mux.Handle(path, methodHandler(byPath[path]))
+	}
+
+	logging.Info("Successfully registered %d handlers from RouteInfo registry", registered)
+}
+
+// methodHandler dispatches requests on one path by method. A path with a single
+// route is served by that route's handler directly, so it keeps full control
+// over method handling.
+func methodHandler(routes []types.RouteInfo) http.Handler {
+	if len(routes) == 1 {
+		return routes[0].Handler
+	}
+
+	handlers := make(map[string]http.HandlerFunc, len(routes))
+	allowed := make([]string, 0, len(routes))
+	for _, route := range routes {
+		method := strings.ToUpper(route.Method)
+		if _, dup := handlers[method]; dup {
+			logging.Warn("Duplicate route %s %s - keeping the first registration", method, route.Path)
+			continue
+		}
+		handlers[method] = route.Handler
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.Method]
+		if !ok && r.Method == http.MethodHead {
+			handler, ok = handlers[http.MethodGet]
+		}
+		if !ok {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			types.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// withWriteTimeout replaces the server's write deadline for a route. A
+// negative timeout removes the deadline.
+func withWriteTimeout(next http.HandlerFunc, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var deadline time.Time
+		if timeout > 0 {
+			deadline = time.Now().Add(timeout)
+		}
+		if err := http.NewResponseController(w).SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			logging.Warn("Failed to set write deadline for %s %s: %v", r.Method, r.URL.Path, err)
+		}
+		next(w, r)
+	}
 }
 
 // GetServeMux returns the internal ServeMux with all handlers registered
@@ -60,6 +167,11 @@ func (hr *HandlerRegistry) GetServeMux() *http.ServeMux {
 	return hr.mux
 }
 
+// GetAdminServeMux returns the ServeMux serving the admin-only routes
+func (hr *HandlerRegistry) GetAdminServeMux() *http.ServeMux {
+	return hr.adminMux
+}
+
 // GetHealthHandler returns the health handler instance for direct access if needed
 func (hr *HandlerRegistry) GetHealthHandler() *HealthHandler {
 	return hr.healthHandler
@@ -74,6 +186,18 @@ func (hr *HandlerRegistry) updateRouteHandlers() {
 			if hr.healthHandler != nil {
 				routes[i].Handler = hr.healthHandler.ServeHTTP
 			}
+		case "/health/live":
+			if hr.healthHandler != nil {
+				routes[i].Handler = hr.healthHandler.LiveHandler
+			}
+		case "/health/ready":
+			if hr.healthHandler != nil {
+				routes[i].Handler = hr.healthHandler.ReadyHandler
+			}
+		case "/health/startup":
+			if hr.healthHandler != nil {
+				routes[i].Handler = hr.healthHandler.StartupHandler
+			}
 		}
 	}
 	
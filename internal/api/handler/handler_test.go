@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodHandler_DispatchesByMethod(t *testing.T) {
+	respond := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(status) }
+	}
+	handler := methodHandler([]types.RouteInfo{
+		{Method: "GET", Path: "/items/{id}", Handler: respond(http.StatusOK)},
+		{Method: "DELETE", Path: "/items/{id}", Handler: respond(http.StatusNoContent)},
+	})
+
+	tests := []struct {
+		method string
+		status int
+	}{
+		{http.MethodGet, http.StatusOK},
+		{http.MethodHead, http.StatusOK},
+		{http.MethodDelete, http.StatusNoContent},
+		{http.MethodPost, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, "/items/1", nil))
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusMethodNotAllowed {
+				assert.Equal(t, "DELETE, GET", w.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestMethodHandler_SingleRouteServedDirectly(t *testing.T) {
+	handler := methodHandler([]types.RouteInfo{
+		{Method: "GET", Path: "/docs", Handler: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/docs", nil))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+}
+
+func TestWithWriteTimeout(t *testing.T) {
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("done"))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stream", withWriteTimeout(slow, types.NoWriteTimeout))
+	mux.HandleFunc("/short", withWriteTimeout(slow, 20*time.Millisecond))
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/stream")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "done", string(body))
+
+	if resp, err := http.Get(srv.URL + "/short"); err == nil {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Empty(t, string(body))
+	}
+}
+
+func TestHandlerRegistry_AdminRoutes(t *testing.T) {
+	registry, err := NewHandlerRegistry()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		mux    *http.ServeMux
+		path   string
+		status int
+	}{
+		{"profiles on admin mux", registry.GetAdminServeMux(), "/debug/pprof/cmdline", http.StatusOK},
+		{"profiles not public", registry.GetServeMux(), "/debug/pprof/cmdline", http.StatusNotFound},
+		{"health public", registry.GetServeMux(), "/health", http.StatusOK},
+		{"health not on admin mux", registry.GetAdminServeMux(), "/health", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestUseAdminListener(t *testing.T) {
+	routes := GetRegisteredRoutes()
+	t.Cleanup(func() {
+		types.UpdateRouteRegistry(routes)
+		types.ClearAdminModules()
+		types.RegisterAdminModule("debug")
+	})
+
+	UseAdminListener(false)
+	registry, err := NewHandlerRegistry()
+	require.NoError(t, err)
+
+	for _, path := range []string{"/health", "/health/ready", "/version"} {
+		w := httptest.NewRecorder()
+		registry.GetServeMux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, "public %s", path)
+
+		w = httptest.NewRecorder()
+		registry.GetAdminServeMux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, "admin %s", path)
+	}
+	for _, route := range GetRegisteredRoutes() {
+		if route.Path == docsReloadPath || route.Path == docsEventsPath {
+			assert.True(t, route.IsAdmin(), route.Path)
+		}
+	}
+	assert.False(t, docsEventsPublic())
+}
+
+func TestUseAdminListener_PublicProbes(t *testing.T) {
+	routes := GetRegisteredRoutes()
+	t.Cleanup(func() { types.UpdateRouteRegistry(routes) })
+
+	UseAdminListener(true)
+	registry, err := NewHandlerRegistry()
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	registry.GetServeMux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, docsEventsPublic())
+}
@@ -4,43 +4,106 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"{{MODULE_NAME}}/internal/config"
+	"{{MODULE_NAME}}/internal/health"
 	"{{MODULE_NAME}}/internal/logging"
 )
 
 // HealthResponse represents the JSON response for health checks
 type HealthResponse struct {
-	Status string `json:"status"`
+	Status string              `json:"status" description:"Service status: HEALTHY, DEGRADED or UNHEALTHY"`
+	Checks []HealthCheckResult `json:"checks,omitempty" description:"Results of the dependency checks behind the status"`
+}
+
+// HealthCheckResult reports the outcome of a single dependency check
+type HealthCheckResult struct {
+	Name       string `json:"name" description:"Check name"`
+	Status     string `json:"status" description:"HEALTHY or UNHEALTHY"`
+	Critical   bool   `json:"critical" description:"Whether a failure makes the service UNHEALTHY rather than DEGRADED"`
+	Error      string `json:"error,omitempty" description:"Failure reason"`
+	DurationMs int64  `json:"duration_ms" description:"How long the check took in milliseconds"`
+	Cached     bool   `json:"cached,omitempty" description:"Whether the result was served from the check cache"`
 }
 
 // HealthHandler handles health check requests
-type HealthHandler struct{}
+type HealthHandler struct {
+	runner *health.Runner
+}
 
-// NewHealthHandler creates a new health handler
+// NewHealthHandler creates a new health handler running the registered checks
 func NewHealthHandler() (*HealthHandler, error) {
-	return &HealthHandler{}, nil
+	return &HealthHandler{
+		runner: health.NewRunner(health.Options{
+			Timeout:  config.GetDuration(config.HealthTimeoutKey),
+			CacheTTL: config.GetDuration(config.HealthCacheTTLKey),
+		}),
+	}, nil
+}
+
+// Runner returns the runner executing the registered checks
+func (h *HealthHandler) Runner() *health.Runner {
+	return h.runner
 }
 
-// ServeHTTP handles health check requests and returns JSON status
+// ServeHTTP handles health check requests and returns the readiness status
 func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	logging.Debug("Processing health check request")
+	h.serveProbe(w, r, health.ProbeReady)
+}
+
+// LiveHandler serves the liveness probe
+func (h *HealthHandler) LiveHandler(w http.ResponseWriter, r *http.Request) {
+	h.serveProbe(w, r, health.ProbeLive)
+}
+
+// ReadyHandler serves the readiness probe
+func (h *HealthHandler) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	h.serveProbe(w, r, health.ProbeReady)
+}
+
+// StartupHandler serves the startup probe
+func (h *HealthHandler) StartupHandler(w http.ResponseWriter, r *http.Request) {
+	h.serveProbe(w, r, health.ProbeStartup)
+}
+
+// serveProbe runs a probe's checks and writes the report. UNHEALTHY reports
+// are returned with 503 so Kubernetes probes fail; DEGRADED ones still pass.
+func (h *HealthHandler) serveProbe(w http.ResponseWriter, r *http.Request, probe health.Probe) {
+	logging.Debug("Processing %s health check request", probe)
 
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	report := h.runner.Run(r.Context(), probe)
 	response := HealthResponse{
-		Status: "HEALTHY",
+		Status: string(report.Status),
+	}
+	for _, result := range report.Checks {
+		response.Checks = append(response.Checks, HealthCheckResult{
+			Name:       result.Name,
+			Status:     string(result.Status),
+			Critical:   result.Critical,
+			Error:      result.Error,
+			DurationMs: result.Duration.Milliseconds(),
+			Cached:     result.Cached,
+		})
+	}
+
+	status := http.StatusOK
+	if report.Status == health.StatusUnhealthy {
+		status = http.StatusServiceUnavailable
+		logging.Warn("Health check %s reported %s", probe, report.Status)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logging.Error("Failed to encode health response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	logging.Debug("Health check completed successfully")
-}
\ No newline at end of file
+	logging.Debug("Health check %s completed with status %s", probe, report.Status)
+}
@@ -6,6 +6,13 @@ import (
 	"{{MODULE_NAME}}/internal/api/types"
 )
 
+// healthProbeResponses documents the 503 returned while a probe fails
+var healthProbeResponses = []types.Response{{
+	Status:      503,
+	Description: "A critical check failed",
+	Type:        reflect.TypeOf(HealthResponse{}),
+}}
+
 func init() {
 	// Register health check endpoint
 	types.RegisterRoute(types.RouteInfo{
@@ -16,5 +23,34 @@ func init() {
 		ResponseType: reflect.TypeOf(HealthResponse{}),
 		Module:       "health",
 		Summary:      "Health check endpoint returning service status",
+		Responses:    healthProbeResponses,
+	})
+
+	// Register Kubernetes probe endpoints
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/health/live",
+		ResponseType: reflect.TypeOf(HealthResponse{}),
+		Module:       "health",
+		Summary:      "Liveness probe; fails only when the process must be restarted",
+		Responses:    healthProbeResponses,
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/health/ready",
+		ResponseType: reflect.TypeOf(HealthResponse{}),
+		Module:       "health",
+		Summary:      "Readiness probe; fails while the service should not receive traffic",
+		Responses:    healthProbeResponses,
+	})
+
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/health/startup",
+		ResponseType: reflect.TypeOf(HealthResponse{}),
+		Module:       "health",
+		Summary:      "Startup probe; fails until the service has finished starting",
+		Responses:    healthProbeResponses,
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_Probes(t *testing.T) {
+	health.ClearChecks()
+	defer health.ClearChecks()
+
+	health.Register(health.Check{Name: "database", Critical: true, Checker: health.CheckerFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})})
+	health.Register(health.Check{Name: "cache", Checker: health.CheckerFunc(func(ctx context.Context) error {
+		return nil
+	})})
+
+	h, err := NewHealthHandler()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		status  int
+		body    HealthResponse
+	}{
+		{"live", h.LiveHandler, http.StatusOK, HealthResponse{Status: "HEALTHY"}},
+		{"ready", h.ReadyHandler, http.StatusServiceUnavailable, HealthResponse{Status: "UNHEALTHY", Checks: []HealthCheckResult{
+			{Name: "cache", Status: "HEALTHY"},
+			{Name: "database", Status: "UNHEALTHY", Critical: true, Error: "connection refused"},
+		}}},
+		{"startup", h.StartupHandler, http.StatusServiceUnavailable, HealthResponse{Status: "UNHEALTHY", Checks: []HealthCheckResult{
+			{Name: "cache", Status: "HEALTHY"},
+			{Name: "database", Status: "UNHEALTHY", Critical: true, Error: "connection refused"},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler(w, httptest.NewRequest(http.MethodGet, "/health/"+tt.name, nil))
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var body HealthResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			for i := range body.Checks {
+				body.Checks[i].DurationMs = 0
+				body.Checks[i].Cached = false
+			}
+			assert.Equal(t, tt.body, body)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/health", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestHealthHandler_NoChecks(t *testing.T) {
+	health.ClearChecks()
+
+	h, err := NewHealthHandler()
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "HEALTHY"}`, w.Body.String())
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"{{MODULE_NAME}}/internal/buildinfo"
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// VersionResponse describes the running build
+type VersionResponse struct {
+	Version   string          `json:"version" description:"Release version, or dev for local builds"`
+	Commit    string          `json:"commit,omitempty" description:"Git commit the binary was built from"`
+	BuildTime string          `json:"build_time,omitempty" description:"Build or commit time in RFC 3339 format"`
+	GoVersion string          `json:"go_version,omitempty" description:"Go toolchain used for the build"`
+	Modified  bool            `json:"modified,omitempty" description:"Whether the build had uncommitted changes"`
+	Modules   []ModuleVersion `json:"modules,omitempty" description:"Dependencies compiled into the binary"`
+}
+
+// ModuleVersion is a dependency compiled into the binary
+type ModuleVersion struct {
+	Path    string `json:"path" description:"Module path"`
+	Version string `json:"version" description:"Module version"`
+}
+
+// VersionHandler returns the build information of the running binary
+func VersionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	info := buildinfo.Get()
+	response := VersionResponse{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildTime: info.BuildTime,
+		GoVersion: info.GoVersion,
+		Modified:  info.Modified,
+	}
+	for _, module := range info.Modules {
+		response.Modules = append(response.Modules, ModuleVersion{Path: module.Path, Version: module.Version})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logging.Error("Failed to encode version response: %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"reflect"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+func init() {
+	// Register build information endpoint
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/version",
+		Handler:      VersionHandler,
+		ResponseType: reflect.TypeOf(VersionResponse{}),
+		Module:       "health",
+		Summary:      "Build information of the running server",
+	})
+}
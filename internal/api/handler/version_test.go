@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/buildinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionHandler(t *testing.T) {
+	version, commit := buildinfo.Version, buildinfo.Commit
+	buildinfo.Version, buildinfo.Commit = "v1.2.3", "abc123"
+	defer func() { buildinfo.Version, buildinfo.Commit = version, commit }()
+
+	w := httptest.NewRecorder()
+	VersionHandler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body VersionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "v1.2.3", body.Version)
+	assert.Equal(t, "abc123", body.Commit)
+	assert.NotEmpty(t, body.GoVersion)
+
+	w = httptest.NewRecorder()
+	VersionHandler(w, httptest.NewRequest(http.MethodPost, "/version", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// ErrorResponse is the standard error body documented for every route
+type ErrorResponse struct {
+	Error   bool   `json:"error"`   // Always true for error responses
+	Message string `json:"message"` // Human-readable error message
+	Status  int    `json:"status"`  // HTTP status code
+}
+
+// WriteError writes a standard JSON error response
+func WriteError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(ErrorResponse{Error: true, Message: message, Status: status}); err != nil {
+		logging.Error("Failed to encode error response: %v", err)
+	}
+}
+
+// StatusError is an error that should be reported with a specific HTTP status
+type StatusError struct {
+	Status  int
+	Message string
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// NewStatusError creates a StatusError with a formatted message
+func NewStatusError(status int, format string, args ...interface{}) *StatusError {
+	return &StatusError{Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+// WriteStatusError writes err as a standard error response, using the status of
+// a *StatusError and 500 for any other error
+func WriteStatusError(w http.ResponseWriter, err error) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		WriteError(w, statusErr.Status, statusErr.Message)
+		return
+	}
+
+	logging.Error("Request failed: %v", err)
+	WriteError(w, http.StatusInternalServerError, "Internal server error")
+}
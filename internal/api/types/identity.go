@@ -0,0 +1,42 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// ClientIdentity describes the verified certificate a client presented over mutual TLS
+type ClientIdentity struct {
+	Subject        string   // Distinguished name, e.g. "CN=billing,O=Example"
+	CommonName     string   // Subject common name
+	DNSNames       []string // DNS subject alternative names
+	URIs           []string // URI subject alternative names, e.g. SPIFFE IDs
+	EmailAddresses []string // Email subject alternative names
+	SerialNumber   string   // Certificate serial number in hex
+	Fingerprint    string   // SHA-256 fingerprint of the certificate in hex
+}
+
+// ClientIdentityFromRequest returns the identity of a client whose certificate
+// was verified against the configured client CA. It reports false for plain
+// HTTP, TLS without a client certificate, or an unverified certificate.
+func ClientIdentityFromRequest(r *http.Request) (ClientIdentity, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ClientIdentity{}, false
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+	fingerprint := sha256.Sum256(cert.Raw)
+	identity := ClientIdentity{
+		Subject:        cert.Subject.String(),
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		SerialNumber:   cert.SerialNumber.Text(16),
+		Fingerprint:    hex.EncodeToString(fingerprint[:]),
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+	return identity, true
+}
@@ -3,20 +3,69 @@ package types
 import (
 	"net/http"
 	"reflect"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"{{MODULE_NAME}}/internal/logging"
 )
 
 // RouteInfo contains metadata for API route registration and documentation generation
 type RouteInfo struct {
-	Method       string           // HTTP method (GET, POST, etc.)
-	Path         string           // Route path (/health)
-	Handler      http.HandlerFunc // Handler function
-	RequestType  reflect.Type     // Request body type (nil for GET)
-	ResponseType reflect.Type     // Success response type
-	Module       string           // Module name for documentation grouping
-	Summary      string           // Optional operation summary
+	Method           string           // HTTP method (GET, POST, etc.)
+	Path             string           // Route path (/health)
+	Handler          http.HandlerFunc // Handler function
+	RequestType      reflect.Type     // Request body type (nil for GET)
+	ResponseType     reflect.Type     // Success response type
+	Module           string           // Module name for documentation grouping
+	Summary          string           // Optional operation summary
+	Version          string           // Optional API version (v1); derived from the path when empty
+	SuccessStatus    int              // Optional success status code; 200 when zero
+	RequestExamples  []Example        // Optional named request body examples
+	ResponseExamples []Example        // Optional named success response examples
+	Responses        []Response       // Optional additional documented responses (503 from health probes)
+	WriteTimeout     time.Duration    // Optional write timeout override; NoWriteTimeout for streaming routes
+	Admin            bool             // Serve on the admin listener only and leave out of the public spec
+}
+
+// IsAdmin reports whether the route is admin-only, either itself or through its module
+func (r RouteInfo) IsAdmin() bool {
+	return r.Admin || IsAdminModule(r.Module)
+}
+
+// NoWriteTimeout disables the write timeout of a route, e.g. for event streams
+const NoWriteTimeout time.Duration = -1
+
+// Response documents a non-default response a route may return
+type Response struct {
+	Status      int          // HTTP status code
+	Description string       // Short description of when the response is returned
+	Type        reflect.Type // Body type; ErrorResponse when nil
+}
+
+// Example is a named request or response payload documented in the spec
+type Example struct {
+	Name    string      // Key under "examples"; unique per route and direction
+	Summary string      // Optional short description
+	Value   interface{} // Payload, usually a value of the route's request or response type
+}
+
+// versionSegment matches path segments that name an API version (v1, v2beta)
+var versionSegment = regexp.MustCompile(`^v[0-9]+[a-z0-9]*$`)
+
+// APIVersion returns the route's API version, falling back to the first
+// version-like path segment. It returns an empty string for unversioned routes.
+func (r RouteInfo) APIVersion() string {
+	if r.Version != "" {
+		return r.Version
+	}
+	for _, segment := range strings.Split(strings.Trim(r.Path, "/"), "/") {
+		if versionSegment.MatchString(segment) {
+			return segment
+		}
+	}
+	return ""
 }
 
 var (
@@ -26,6 +75,38 @@ var (
 	registryMutex sync.RWMutex
 )
 
+var (
+	// adminModules holds the modules whose routes are all admin-only
+	adminModules = make(map[string]bool)
+	// adminModulesMutex protects adminModules
+	adminModulesMutex sync.RWMutex
+)
+
+// RegisterAdminModule marks every route of a module as admin-only.
+// Modules call it from init(), like RegisterRoute.
+func RegisterAdminModule(module string) {
+	adminModulesMutex.Lock()
+	defer adminModulesMutex.Unlock()
+
+	adminModules[module] = true
+}
+
+// IsAdminModule reports whether a module was registered as admin-only
+func IsAdminModule(module string) bool {
+	adminModulesMutex.RLock()
+	defer adminModulesMutex.RUnlock()
+
+	return adminModules[module]
+}
+
+// ClearAdminModules removes every admin module registration (used for testing)
+func ClearAdminModules() {
+	adminModulesMutex.Lock()
+	defer adminModulesMutex.Unlock()
+
+	adminModules = make(map[string]bool)
+}
+
 // RegisterRoute adds a new route to the global registry
 // This function is called by modules during their init() phase
 func RegisterRoute(route RouteInfo) {
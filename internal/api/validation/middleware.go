@@ -0,0 +1,236 @@
+// Package validation provides middleware that checks requests and responses
+// against the OpenAPI document generated by cmd/generate-openapi.
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/logging"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// DefaultMaxBodyBytes caps the request bodies read for validation when
+// Options.MaxBodyBytes is zero
+const DefaultMaxBodyBytes = 10 << 20
+
+// Options controls which parts of the exchange are validated
+type Options struct {
+	// MaxBodyBytes is the largest request body read for validation;
+	// DefaultMaxBodyBytes when zero
+	MaxBodyBytes int64
+	// ValidateResponses checks every response against the documented responses
+	ValidateResponses bool
+	// Strict replaces responses that violate the spec with a 500 error.
+	// Intended for integration tests; implies ValidateResponses.
+	Strict bool
+}
+
+// Middleware returns middleware that validates requests against doc.
+// Requests to undocumented paths are passed through unchanged, as are
+// responses that are not JSON, such as event streams.
+func Middleware(doc *openapi.Document, opts Options) func(http.Handler) http.Handler {
+	if opts.Strict {
+		opts.ValidateResponses = true
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			match := doc.FindOperation(r.Method, r.URL.Path)
+			if match == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Only bodies the operation documents are buffered for validation
+			var body []byte
+			if match.Operation != nil && match.Operation.RequestBody != nil {
+				var err error
+				body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, opts.MaxBodyBytes))
+				if err != nil {
+					var tooLarge *http.MaxBytesError
+					if errors.As(err, &tooLarge) {
+						types.WriteError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+						return
+					}
+					types.WriteError(w, http.StatusBadRequest, "Failed to read request body")
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			violations := doc.ValidateMatchedRequest(match, r, body)
+			if len(violations) > 0 {
+				writeViolations(w, r, match, violations)
+				return
+			}
+
+			if !opts.ValidateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := newRecorder(w, !opts.Strict)
+			next.ServeHTTP(rec, r)
+			if rec.skipped {
+				return
+			}
+
+			responseViolations := doc.ValidateResponse(match.Operation, rec.status, rec.Header(), rec.body.Bytes())
+			for _, v := range responseViolations {
+				logging.Warn("Response contract violation on %s %s (status %d): %s", r.Method, match.Template, rec.status, v.Error())
+			}
+
+			if !opts.Strict {
+				return
+			}
+			if len(responseViolations) > 0 {
+				types.WriteError(w, http.StatusInternalServerError, "Response violates API contract: "+joinErrors(responseViolations))
+				return
+			}
+			rec.flush()
+		})
+	}
+}
+
+// writeViolations writes the standard error body for request violations,
+// using the most severe status among them
+func writeViolations(w http.ResponseWriter, r *http.Request, match *openapi.Match, violations []openapi.RequestViolation) {
+	status := http.StatusUnprocessableEntity
+	errs := make([]openapi.ValidationError, 0, len(violations))
+	for _, v := range violations {
+		switch {
+		case v.Status == http.StatusMethodNotAllowed:
+			status = v.Status
+		case v.Status == http.StatusBadRequest && status != http.StatusMethodNotAllowed:
+			status = v.Status
+		}
+		errs = append(errs, v.ValidationError)
+	}
+
+	logging.Debug("Rejected %s %s (%s): %s", r.Method, r.URL.Path, match.Template, joinErrors(errs))
+	types.WriteError(w, status, joinErrors(errs))
+}
+
+// joinErrors formats validation errors as a single message
+func joinErrors(errs []openapi.ValidationError) string {
+	parts := make([]string, 0, len(errs))
+	for _, e := range errs {
+		parts = append(parts, e.Error())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// recorder captures the response so it can be validated. In pass-through
+// mode the response is also written to the client as it is produced;
+// otherwise it is buffered until flush is called. Responses that are not
+// JSON are skipped: they always pass through and are not recorded.
+type recorder struct {
+	w           http.ResponseWriter
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	passThrough bool
+	skipped     bool
+	wroteHeader bool
+}
+
+// newRecorder creates a recorder wrapping w
+func newRecorder(w http.ResponseWriter, passThrough bool) *recorder {
+	rec := &recorder{w: w, status: http.StatusOK, passThrough: passThrough}
+	if passThrough {
+		rec.header = w.Header()
+	} else {
+		rec.header = make(http.Header)
+	}
+	return rec
+}
+
+// Header returns the response headers
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+// WriteHeader records the status code
+func (rec *recorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.wroteHeader = true
+	rec.status = status
+	if contentType := rec.header.Get("Content-Type"); contentType != "" && !isJSON(contentType) {
+		rec.skip()
+	}
+	if rec.passThrough {
+		rec.w.WriteHeader(status)
+	}
+}
+
+// Write records the body
+func (rec *recorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	if !rec.skipped {
+		rec.body.Write(b)
+	}
+	if rec.passThrough {
+		return rec.w.Write(b)
+	}
+	return len(b), nil
+}
+
+// Flush sends buffered data to the client when the response passes through
+func (rec *recorder) Flush() {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	if rec.passThrough {
+		_ = http.NewResponseController(rec.w).Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.w
+}
+
+// skip stops recording and passes the rest of the response through
+func (rec *recorder) skip() {
+	rec.skipped = true
+	if !rec.passThrough {
+		for k, v := range rec.header {
+			rec.w.Header()[k] = v
+		}
+		rec.header = rec.w.Header()
+		rec.passThrough = true
+	}
+}
+
+// isJSON reports whether a Content-Type header carries JSON
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return openapi.IsJSONMediaType(mediaType)
+}
+
+// flush writes a buffered response to the underlying writer
+func (rec *recorder) flush() {
+	for k, v := range rec.header {
+		rec.w.Header()[k] = v
+	}
+	rec.w.WriteHeader(rec.status)
+	rec.w.Write(rec.body.Bytes())
+}
@@ -0,0 +1,164 @@
+package validation
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test API", "version": "1.0.0"},
+  "paths": {
+    "/items": {
+      "post": {
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}
+        },
+        "responses": {
+          "200": {"description": "Success", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}},
+          "422": {"description": "Unprocessable Entity", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Item": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}},
+      "ErrorResponse": {
+        "type": "object",
+        "required": ["error", "message", "status"],
+        "properties": {"error": {"type": "boolean"}, "message": {"type": "string"}, "status": {"type": "integer"}}
+      }
+    }
+  }
+}`
+
+func newTestHandler(t *testing.T, opts Options, respond string) http.Handler {
+	doc, err := openapi.ParseJSON([]byte(testSpec))
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(respond))
+	})
+	return Middleware(doc, opts)(next)
+}
+
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_RequestValidation(t *testing.T) {
+	h := newTestHandler(t, Options{}, `{"name": "ok"}`)
+
+	w := serve(h, http.MethodPost, "/items", `{"name": "widget"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(h, http.MethodPost, "/items", `{"name": 5}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var errResp types.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.True(t, errResp.Error)
+	assert.Equal(t, http.StatusUnprocessableEntity, errResp.Status)
+	assert.Contains(t, errResp.Message, "/name")
+
+	w = serve(h, http.MethodPost, "/items", `not json`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Undocumented paths pass through untouched
+	w = serve(h, http.MethodGet, "/other", ``)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMiddleware_ResponseValidationLogsOnly(t *testing.T) {
+	h := newTestHandler(t, Options{ValidateResponses: true}, `{"wrong": true}`)
+
+	w := serve(h, http.MethodPost, "/items", `{"name": "widget"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"wrong": true}`, w.Body.String())
+}
+
+func TestMiddleware_StrictMode(t *testing.T) {
+	h := newTestHandler(t, Options{Strict: true}, `{"wrong": true}`)
+
+	w := serve(h, http.MethodPost, "/items", `{"name": "widget"}`)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "/name")
+
+	h = newTestHandler(t, Options{Strict: true}, `{"name": "ok"}`)
+	w = serve(h, http.MethodPost, "/items", `{"name": "widget"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"name": "ok"}`, w.Body.String())
+}
+
+func TestMiddleware_BodyTooLarge(t *testing.T) {
+	h := newTestHandler(t, Options{}, `{"name": "ok"}`)
+	limited := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, 8)
+		h.ServeHTTP(w, r)
+	})
+
+	w := serve(limited, http.MethodPost, "/items", `{"name": "a much longer widget name"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestMiddleware_MaxBodyBytes(t *testing.T) {
+	h := newTestHandler(t, Options{MaxBodyBytes: 8}, `{"name": "ok"}`)
+
+	w := serve(h, http.MethodPost, "/items", `{"name": "a much longer widget name"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestMiddleware_UndocumentedBodiesNotBuffered(t *testing.T) {
+	doc, err := openapi.ParseJSON([]byte(testSpec))
+	require.NoError(t, err)
+	upload := strings.Repeat("x", 64)
+	body := strings.NewReader(upload)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, len(upload), body.Len(), "body was read before the handler")
+		received, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, upload, string(received))
+		w.WriteHeader(http.StatusCreated)
+	})
+	h := Middleware(doc, Options{MaxBodyBytes: 8})(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", body)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestMiddleware_StreamsPassThrough(t *testing.T) {
+	doc, err := openapi.ParseJSON([]byte(testSpec))
+	require.NoError(t, err)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: reload\n\n")
+		require.NoError(t, http.NewResponseController(w).Flush())
+	})
+	h := Middleware(doc, Options{Strict: true})(next)
+
+	w := serve(h, http.MethodPost, "/items", `{"name": "widget"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "data: reload\n\n", w.Body.String())
+}
@@ -0,0 +1,62 @@
+package apispec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+// validateExamples checks that every route example has a unique name and
+// encodes to JSON
+func validateExamples(routes []types.RouteInfo) error {
+	for _, route := range routes {
+		for direction, examples := range map[string][]types.Example{
+			"request":  route.RequestExamples,
+			"response": route.ResponseExamples,
+		} {
+			seen := make(map[string]bool)
+			for _, example := range examples {
+				if example.Name == "" {
+					return fmt.Errorf("%s %s has an unnamed %s example", route.Method, route.Path, direction)
+				}
+				if seen[example.Name] {
+					return fmt.Errorf("%s %s has duplicate %s example %q", route.Method, route.Path, direction, example.Name)
+				}
+				seen[example.Name] = true
+				if _, err := exampleValue(example); err != nil {
+					return fmt.Errorf("%s %s %s example %q: %w", route.Method, route.Path, direction, example.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// buildExamples converts route examples into the media type examples map
+func buildExamples(examples []types.Example) map[string]ExampleObject {
+	if len(examples) == 0 {
+		return nil
+	}
+	out := make(map[string]ExampleObject, len(examples))
+	for _, example := range examples {
+		// Values were checked by validateExamples
+		value, _ := exampleValue(example)
+		out[example.Name] = ExampleObject{Summary: example.Summary, Value: value}
+	}
+	return out
+}
+
+// exampleValue round-trips an example through JSON so the YAML and JSON
+// specs use the same field names as the wire format
+func exampleValue(example types.Example) (interface{}, error) {
+	data, err := json.Marshal(example.Value)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
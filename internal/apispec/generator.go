@@ -0,0 +1,192 @@
+// Package apispec builds the OpenAPI specification from the route registry.
+// It works from registered routes only, so the server can build the spec
+// at runtime without access to its source.
+package apispec
+
+import (
+	"fmt"
+	"reflect"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/buildinfo"
+	"{{MODULE_NAME}}/pkg/schema"
+)
+
+// Generator builds OpenAPI specifications from the route registry
+type Generator struct {
+	routes       []types.RouteInfo
+	typeSchemas  map[string]interface{}
+	version      string
+	includeAdmin bool
+	// referenced holds nested struct types whose schemas are still to be generated
+	referenced []reflect.Type
+}
+
+// NewGenerator creates a new OpenAPI generator. The spec's info.version
+// defaults to the version of the running binary.
+func NewGenerator() *Generator {
+	return &Generator{
+		typeSchemas: make(map[string]interface{}),
+		version:     buildinfo.Get().Version,
+	}
+}
+
+// SetVersion overrides the spec's info.version
+func (g *Generator) SetVersion(version string) {
+	g.version = version
+}
+
+// SetIncludeAdmin documents admin-only routes, which are left out by default
+func (g *Generator) SetIncludeAdmin(include bool) {
+	g.includeAdmin = include
+}
+
+// GenerateSpec generates a complete OpenAPI specification
+func (g *Generator) GenerateSpec() (string, error) {
+	if err := g.prepare(); err != nil {
+		return "", err
+	}
+
+	// Build the OpenAPI spec
+	spec := g.buildOpenAPISpec()
+	
+	return spec, nil
+}
+
+// prepare collects the registered routes and generates the schemas every
+// spec variant needs
+func (g *Generator) prepare() error {
+	// Get routes from the registry (populated by init() functions)
+	g.routes = nil
+	for _, route := range types.GetRegisteredRoutes() {
+		if route.IsAdmin() && !g.includeAdmin {
+			continue
+		}
+		g.routes = append(g.routes, route)
+	}
+	
+	if len(g.routes) == 0 {
+		return fmt.Errorf("no routes discovered in registry")
+	}
+
+	if err := validateExamples(g.routes); err != nil {
+		return err
+	}
+
+	// Generate type schemas
+	if err := g.generateSchemas(); err != nil {
+		return fmt.Errorf("failed to generate schemas: %w", err)
+	}
+	
+	// Add standard schemas
+	g.addStandardSchemas()
+
+	return nil
+}
+
+// generateSchemas generates JSON schemas for request/response types
+func (g *Generator) generateSchemas() error {
+	for _, route := range g.routes {
+		if route.RequestType != nil {
+			schema, err := g.generateTypeSchema(route.RequestType)
+			if err != nil {
+				return fmt.Errorf("failed to generate schema for request type %v: %w", route.RequestType, err)
+			}
+			g.typeSchemas[g.getTypeName(route.RequestType)] = schema
+		}
+
+		if route.ResponseType != nil {
+			schema, err := g.generateTypeSchema(route.ResponseType)
+			if err != nil {
+				return fmt.Errorf("failed to generate schema for response type %v: %w", route.ResponseType, err)
+			}
+			g.typeSchemas[g.getTypeName(route.ResponseType)] = schema
+		}
+
+		for _, response := range route.Responses {
+			if response.Type == nil {
+				continue
+			}
+			schema, err := g.generateTypeSchema(response.Type)
+			if err != nil {
+				return fmt.Errorf("failed to generate schema for %d response type %v: %w", response.Status, response.Type, err)
+			}
+			g.typeSchemas[g.getTypeName(response.Type)] = schema
+		}
+	}
+
+	// Named structs nested in the types above become components of their own
+	for len(g.referenced) > 0 {
+		t := g.referenced[0]
+		g.referenced = g.referenced[1:]
+		name := g.getTypeName(t)
+		if _, done := g.typeSchemas[name]; done {
+			continue
+		}
+		schema, err := g.generateTypeSchema(t)
+		if err != nil {
+			return fmt.Errorf("failed to generate schema for nested type %v: %w", t, err)
+		}
+		g.typeSchemas[name] = schema
+	}
+
+	return nil
+}
+
+// generateTypeSchema generates a JSON schema for a Go type using reflection.
+// Nested named structs are referenced and queued for generation; anonymous
+// structs are inlined.
+func (g *Generator) generateTypeSchema(t reflect.Type) (map[string]interface{}, error) {
+	return schema.New(schema.Options{
+		Dialect: schema.OpenAPI30,
+		Ref: func(t reflect.Type) (string, bool) {
+			g.referenced = append(g.referenced, t)
+			return "#/components/schemas/" + g.getTypeName(t), true
+		},
+	}).Schema(t)
+}
+
+// getTypeName returns a clean name for a type to use as a schema reference
+func (g *Generator) getTypeName(t reflect.Type) string {
+	return schema.TypeName(t)
+}
+
+// addStandardSchemas adds common schemas used across all APIs
+func (g *Generator) addStandardSchemas() {
+	// Standard error response schema
+	g.typeSchemas["ErrorResponse"] = map[string]interface{}{
+		"type": "object",
+		"required": []string{"error", "message", "status"},
+		"properties": map[string]interface{}{
+			"error": map[string]interface{}{
+				"type": "boolean",
+				"description": "Indicates this is an error response",
+			},
+			"message": map[string]interface{}{
+				"type": "string",
+				"description": "Human-readable error message",
+			},
+			"status": map[string]interface{}{
+				"type": "integer",
+				"description": "HTTP status code",
+			},
+		},
+	}
+}
+
+// GetDiscoveredRoutes returns the routes discovered by the generator
+func (g *Generator) GetDiscoveredRoutes() []types.RouteInfo {
+	return g.routes
+}
+
+// GenerateJSONSpec generates a complete OpenAPI specification in JSON format
+func (g *Generator) GenerateJSONSpec() (string, error) {
+	if err := g.prepare(); err != nil {
+		return "", err
+	}
+
+	// Build the OpenAPI spec in JSON
+	spec := g.buildOpenAPIJSONSpec()
+	
+	return spec, nil
+}
@@ -0,0 +1,163 @@
+package apispec
+
+import (
+	"reflect"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerator(t *testing.T) {
+	gen := NewGenerator()
+	assert.NotNil(t, gen)
+	assert.NotNil(t, gen.typeSchemas)
+	assert.Equal(t, 0, len(gen.routes))
+}
+
+func TestGetTypeName(t *testing.T) {
+	gen := NewGenerator()
+
+	tests := []struct {
+		name     string
+		input    reflect.Type
+		expected string
+	}{
+		{
+			name:     "simple string type",
+			input:    reflect.TypeOf(""),
+			expected: "string",
+		},
+		{
+			name:     "simple int type",
+			input:    reflect.TypeOf(0),
+			expected: "int",
+		},
+		{
+			name:     "struct type",
+			input:    reflect.TypeOf(types.RouteInfo{}),
+			expected: "RouteInfo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := gen.getTypeName(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestAddStandardSchemas(t *testing.T) {
+	gen := NewGenerator()
+	gen.addStandardSchemas()
+
+	assert.Contains(t, gen.typeSchemas, "ErrorResponse")
+
+	errorSchema := gen.typeSchemas["ErrorResponse"]
+	schemaMap, ok := errorSchema.(map[string]interface{})
+	assert.True(t, ok)
+
+	assert.Equal(t, "object", schemaMap["type"])
+	assert.Contains(t, schemaMap, "properties")
+	assert.Contains(t, schemaMap, "required")
+}
+
+func TestGenerateTypeSchema_ExampleTags(t *testing.T) {
+	type tagged struct {
+		Name  string   `json:"name" example:"Ada Lovelace"`
+		Age   int      `json:"age" example:"36"`
+		Score float64  `json:"score" example:"9.5"`
+		Admin bool     `json:"admin" example:"true"`
+		Tags  []string `json:"tags" example:"[\"math\",\"poetry\"]"`
+		Plain string   `json:"plain"`
+	}
+
+	gen := NewGenerator()
+	schema, err := gen.generateTypeSchema(reflect.TypeOf(tagged{}))
+	assert.NoError(t, err)
+
+	properties := schema["properties"].(map[string]interface{})
+	example := func(name string) interface{} {
+		return properties[name].(map[string]interface{})["example"]
+	}
+	assert.Equal(t, "Ada Lovelace", example("name"))
+	assert.Equal(t, int64(36), example("age"))
+	assert.Equal(t, 9.5, example("score"))
+	assert.Equal(t, true, example("admin"))
+	assert.Equal(t, []interface{}{"math", "poetry"}, example("tags"))
+	assert.NotContains(t, properties["plain"], "example")
+}
+
+func TestGenerateTypeSchema_EnumAndNullable(t *testing.T) {
+	type tagged struct {
+		Role     string  `json:"role" enum:"admin, member"`
+		Level    int     `json:"level" enum:"1,2,3"`
+		Nickname *string `json:"nickname,omitempty"`
+		Plain    string  `json:"plain" description:"Free text"`
+	}
+
+	gen := NewGenerator()
+	schema, err := gen.generateTypeSchema(reflect.TypeOf(tagged{}))
+	assert.NoError(t, err)
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"admin", "member"}, properties["role"].(map[string]interface{})["enum"])
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, properties["level"].(map[string]interface{})["enum"])
+	assert.Equal(t, true, properties["nickname"].(map[string]interface{})["nullable"])
+	assert.NotContains(t, properties["plain"], "nullable")
+	assert.Equal(t, "Free text", properties["plain"].(map[string]interface{})["description"])
+}
+
+func TestPrepare_AdminRoutes(t *testing.T) {
+	types.ClearRegistry()
+	types.ClearAdminModules()
+	defer types.ClearRegistry()
+	defer types.ClearAdminModules()
+
+	types.RegisterAdminModule("debug")
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/items", Module: "items"})
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/config", Module: "items", Admin: true})
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/debug/vars", Module: "debug"})
+
+	gen := NewGenerator()
+	require.NoError(t, gen.prepare())
+	assert.Len(t, gen.GetDiscoveredRoutes(), 1)
+
+	gen = NewGenerator()
+	gen.SetIncludeAdmin(true)
+	require.NoError(t, gen.prepare())
+	assert.Len(t, gen.GetDiscoveredRoutes(), 3)
+}
+
+type testAddress struct {
+	City string `json:"city"`
+}
+
+type testCustomer struct {
+	Name     string        `json:"name"`
+	Billing  testAddress   `json:"billing"`
+	Shipping []testAddress `json:"shipping"`
+	Meta     struct {
+		Source string `json:"source"`
+	} `json:"meta"`
+}
+
+func TestGenerateSchemas_NestedStructsReferenced(t *testing.T) {
+	saved := types.GetRegisteredRoutes()
+	types.ClearRegistry()
+	defer types.UpdateRouteRegistry(saved)
+
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/customers", Module: "customers", ResponseType: reflect.TypeOf(testCustomer{})})
+
+	gen := NewGenerator()
+	require.NoError(t, gen.prepare())
+
+	customer := gen.typeSchemas["testCustomer"].(map[string]interface{})
+	properties := customer["properties"].(map[string]interface{})
+	assert.Equal(t, "#/components/schemas/testAddress", properties["billing"].(map[string]interface{})["$ref"])
+	assert.Equal(t, "#/components/schemas/testAddress", properties["shipping"].(map[string]interface{})["items"].(map[string]interface{})["$ref"])
+	assert.Contains(t, properties["meta"], "properties", "anonymous structs stay inline")
+	assert.Contains(t, gen.typeSchemas, "testAddress")
+}
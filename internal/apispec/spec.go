@@ -1,8 +1,9 @@
-package analyzer
+package apispec
 
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"{{MODULE_NAME}}/internal/api/types"
@@ -36,6 +37,7 @@ type PathItem struct {
 	Get    *Operation `yaml:"get,omitempty" json:"get,omitempty"`
 	Post   *Operation `yaml:"post,omitempty" json:"post,omitempty"`
 	Put    *Operation `yaml:"put,omitempty" json:"put,omitempty"`
+	Patch  *Operation `yaml:"patch,omitempty" json:"patch,omitempty"`
 	Delete *Operation `yaml:"delete,omitempty" json:"delete,omitempty"`
 }
 
@@ -45,10 +47,19 @@ type Operation struct {
 	Summary     string              `yaml:"summary,omitempty" json:"summary,omitempty"`
 	Description string              `yaml:"description,omitempty" json:"description,omitempty"`
 	OperationID string              `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+	Parameters  []Parameter         `yaml:"parameters,omitempty" json:"parameters,omitempty"`
 	RequestBody *RequestBody        `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
 	Responses   map[string]Response `yaml:"responses" json:"responses"`
 }
 
+// Parameter describes a path parameter
+type Parameter struct {
+	Name     string                 `yaml:"name" json:"name"`
+	In       string                 `yaml:"in" json:"in"`
+	Required bool                   `yaml:"required" json:"required"`
+	Schema   map[string]interface{} `yaml:"schema" json:"schema"`
+}
+
 // RequestBody describes the request body
 type RequestBody struct {
 	Description string                     `yaml:"description,omitempty" json:"description,omitempty"`
@@ -58,7 +69,14 @@ type RequestBody struct {
 
 // MediaTypeObject provides schema and examples for media type
 type MediaTypeObject struct {
-	Schema SchemaRef `yaml:"schema" json:"schema"`
+	Schema   SchemaRef                `yaml:"schema" json:"schema"`
+	Examples map[string]ExampleObject `yaml:"examples,omitempty" json:"examples,omitempty"`
+}
+
+// ExampleObject is a named example payload
+type ExampleObject struct {
+	Summary string      `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Value   interface{} `yaml:"value" json:"value"`
 }
 
 // Response describes a single response
@@ -79,12 +97,17 @@ type Components struct {
 
 // buildOpenAPISpec builds the complete OpenAPI specification
 func (g *Generator) buildOpenAPISpec() string {
-	spec := OpenAPISpec{
+	return renderYAML(g.buildSpecDocument("{{API_TITLE}}", g.routes, g.typeSchemas))
+}
+
+// buildSpecDocument assembles an OpenAPI document for the given routes
+func (g *Generator) buildSpecDocument(title string, routes []types.RouteInfo, schemas map[string]interface{}) OpenAPISpec {
+	return OpenAPISpec{
 		OpenAPI: "3.0.3",
 		Info: Info{
-			Title:       "{{API_TITLE}}",
+			Title:       title,
 			Description: "Auto-generated API documentation with zero-maintenance updates",
-			Version:     "1.0.0",
+			Version:     g.version,
 		},
 		Servers: []Server{
 			{
@@ -96,11 +119,13 @@ func (g *Generator) buildOpenAPISpec() string {
 				Description: "Development server",
 			},
 		},
-		Paths:      g.buildPaths(),
-		Components: Components{Schemas: g.typeSchemas},
+		Paths:      g.buildPaths(routes),
+		Components: Components{Schemas: schemas},
 	}
+}
 
-	// Convert to YAML
+// renderYAML marshals a spec to YAML with the generated-file header
+func renderYAML(spec OpenAPISpec) string {
 	yamlData, err := yaml.Marshal(spec)
 	if err != nil {
 		return fmt.Sprintf("# Error generating YAML: %v\n", err)
@@ -112,11 +137,21 @@ func (g *Generator) buildOpenAPISpec() string {
 	return header + string(yamlData)
 }
 
+// renderJSON marshals a spec to indented JSON
+func renderJSON(spec OpenAPISpec) string {
+	jsonData, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("{\"error\": \"Failed to generate JSON: %v\"}", err)
+	}
+
+	return string(jsonData)
+}
+
 // buildPaths builds the paths section of the OpenAPI spec
-func (g *Generator) buildPaths() map[string]PathItem {
+func (g *Generator) buildPaths(routes []types.RouteInfo) map[string]PathItem {
 	paths := make(map[string]PathItem)
 
-	for _, route := range g.routes {
+	for _, route := range routes {
 		pathItem, exists := paths[route.Path]
 		if !exists {
 			pathItem = PathItem{}
@@ -131,6 +166,8 @@ func (g *Generator) buildPaths() map[string]PathItem {
 			pathItem.Post = operation
 		case "PUT":
 			pathItem.Put = operation
+		case "PATCH":
+			pathItem.Patch = operation
 		case "DELETE":
 			pathItem.Delete = operation
 		}
@@ -147,6 +184,7 @@ func (g *Generator) buildOperation(route types.RouteInfo) *Operation {
 		Tags:        []string{route.Module},
 		Summary:     route.Summary,
 		OperationID: g.generateOperationID(route),
+		Parameters:  buildPathParameters(route.Path),
 		Responses:   g.buildResponses(route),
 	}
 
@@ -158,6 +196,32 @@ func (g *Generator) buildOperation(route types.RouteInfo) *Operation {
 	return operation
 }
 
+// buildPathParameters documents the {name} wildcards of a ServeMux pattern
+func buildPathParameters(path string) []Parameter {
+	var params []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.Trim(segment, "{}"), "...")
+		if name == "$" || name == "" {
+			continue
+		}
+		params = append(params, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   map[string]interface{}{"type": "string"},
+		})
+	}
+	return params
+}
+
+// OperationID returns the operationId the spec uses for a route
+func (g *Generator) OperationID(route types.RouteInfo) string {
+	return g.generateOperationID(route)
+}
+
 // generateOperationID generates a unique operation ID
 func (g *Generator) generateOperationID(route types.RouteInfo) string {
 	// Convert path to camelCase operation name
@@ -211,6 +275,11 @@ func (g *Generator) generateOperationID(route types.RouteInfo) string {
 		}
 	}
 
+	// Subtree patterns would otherwise share the ID of the exact path
+	if len(route.Path) > 1 && strings.HasSuffix(route.Path, "/") {
+		operationParts = append(operationParts, "Subtree")
+	}
+
 	return strings.Join(operationParts, "")
 }
 
@@ -226,6 +295,7 @@ func (g *Generator) buildRequestBody(route types.RouteInfo) *RequestBody {
 				Schema: SchemaRef{
 					Ref: fmt.Sprintf("#/components/schemas/%s", typeName),
 				},
+				Examples: buildExamples(route.RequestExamples),
 			},
 		},
 	}
@@ -236,20 +306,25 @@ func (g *Generator) buildResponses(route types.RouteInfo) map[string]Response {
 	responses := make(map[string]Response)
 
 	// Success response
+	successCode := "200"
+	if route.SuccessStatus != 0 {
+		successCode = strconv.Itoa(route.SuccessStatus)
+	}
 	if route.ResponseType != nil {
 		typeName := g.getTypeName(route.ResponseType)
-		responses["200"] = Response{
+		responses[successCode] = Response{
 			Description: "Success",
 			Content: map[string]MediaTypeObject{
 				"application/json": {
 					Schema: SchemaRef{
 						Ref: fmt.Sprintf("#/components/schemas/%s", typeName),
 					},
+					Examples: buildExamples(route.ResponseExamples),
 				},
 			},
 		}
 	} else {
-		responses["200"] = Response{
+		responses[successCode] = Response{
 			Description: "Success",
 		}
 	}
@@ -291,37 +366,28 @@ func (g *Generator) buildResponses(route types.RouteInfo) map[string]Response {
 		}
 	}
 
+	// Route-specific responses
+	for _, response := range route.Responses {
+		ref := "#/components/schemas/ErrorResponse"
+		if response.Type != nil {
+			ref = fmt.Sprintf("#/components/schemas/%s", g.getTypeName(response.Type))
+		}
+		responses[strconv.Itoa(response.Status)] = Response{
+			Description: response.Description,
+			Content: map[string]MediaTypeObject{
+				"application/json": {
+					Schema: SchemaRef{
+						Ref: ref,
+					},
+				},
+			},
+		}
+	}
+
 	return responses
 }
 
 // buildOpenAPIJSONSpec builds the complete OpenAPI specification in JSON format
 func (g *Generator) buildOpenAPIJSONSpec() string {
-	spec := OpenAPISpec{
-		OpenAPI: "3.0.3",
-		Info: Info{
-			Title:       "{{API_TITLE}}",
-			Description: "Auto-generated API documentation with zero-maintenance updates",
-			Version:     "1.0.0",
-		},
-		Servers: []Server{
-			{
-				URL:         "{{API_BASE_URL}}",
-				Description: "Production server",
-			},
-			{
-				URL:         "http://localhost:8080",
-				Description: "Development server",
-			},
-		},
-		Paths:      g.buildPaths(),
-		Components: Components{Schemas: g.typeSchemas},
-	}
-
-	// Convert to JSON
-	jsonData, err := json.MarshalIndent(spec, "", "  ")
-	if err != nil {
-		return fmt.Sprintf("{\"error\": \"Failed to generate JSON: %v\"}", err)
-	}
-
-	return string(jsonData)
-}
\ No newline at end of file
+	return renderJSON(g.buildSpecDocument("{{API_TITLE}}", g.routes, g.typeSchemas))
+}
@@ -0,0 +1,185 @@
+package apispec
+
+import (
+	"reflect"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateOperationID(t *testing.T) {
+	gen := NewGenerator()
+
+	tests := []struct {
+		name     string
+		route    types.RouteInfo
+		expected string
+	}{
+		{
+			name: "GET health endpoint",
+			route: types.RouteInfo{
+				Method: "GET",
+				Path:   "/health",
+			},
+			expected: "gethealth",
+		},
+		{
+			name: "POST create endpoint",
+			route: types.RouteInfo{
+				Method: "POST",
+				Path:   "/create-user",
+			},
+			expected: "createcreateUser",
+		},
+		{
+			name: "DELETE endpoint",
+			route: types.RouteInfo{
+				Method: "DELETE",
+				Path:   "/delete-user",
+			},
+			expected: "deletedeleteUser",
+		},
+		{
+			name: "GET subtree pattern",
+			route: types.RouteInfo{
+				Method: "GET",
+				Path:   "/docs/assets/",
+			},
+			expected: "getdocsAssetsSubtree",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := gen.generateOperationID(tt.route)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBuildResponses(t *testing.T) {
+	gen := NewGenerator()
+
+	// Test route without response type
+	route := types.RouteInfo{
+		Method: "GET",
+		Path:   "/health",
+	}
+
+	responses := gen.buildResponses(route)
+
+	// Should have standard responses
+	assert.Contains(t, responses, "200")
+	assert.Contains(t, responses, "400")
+	assert.Contains(t, responses, "500")
+
+	// GET should not have 422
+	assert.NotContains(t, responses, "422")
+
+	// Test POST route
+	route.Method = "POST"
+	responses = gen.buildResponses(route)
+
+	// POST should have 422
+	assert.Contains(t, responses, "422")
+}
+
+func TestBuildRequestBody(t *testing.T) {
+	gen := NewGenerator()
+
+	route := types.RouteInfo{
+		Method:      "POST",
+		Path:        "/create-user",
+		Summary:     "Create a new user",
+		RequestType: reflect.TypeOf(""),
+	}
+
+	requestBody := gen.buildRequestBody(route)
+
+	assert.NotNil(t, requestBody)
+	assert.True(t, requestBody.Required)
+	assert.Contains(t, requestBody.Content, "application/json")
+	assert.Contains(t, requestBody.Description, "Create a new user")
+}
+
+func TestBuildOperation_PathParametersAndSuccessStatus(t *testing.T) {
+	gen := NewGenerator()
+
+	operation := gen.buildOperation(types.RouteInfo{
+		Method:        "PATCH",
+		Path:          "/api/v1/users/{user_id}/files/{path...}",
+		SuccessStatus: 202,
+	})
+
+	assert.Equal(t, []Parameter{
+		{Name: "user_id", In: "path", Required: true, Schema: map[string]interface{}{"type": "string"}},
+		{Name: "path", In: "path", Required: true, Schema: map[string]interface{}{"type": "string"}},
+	}, operation.Parameters)
+	assert.Contains(t, operation.Responses, "202")
+	assert.NotContains(t, operation.Responses, "200")
+
+	paths := gen.buildPaths([]types.RouteInfo{{Method: "PATCH", Path: "/items"}})
+	assert.NotNil(t, paths["/items"].Patch)
+}
+
+func TestBuildOperation_Examples(t *testing.T) {
+	type widget struct {
+		Name  string `json:"name"`
+		Count int    `json:"count,omitempty"`
+	}
+	gen := NewGenerator()
+	route := types.RouteInfo{
+		Method:       "POST",
+		Path:         "/widgets",
+		RequestType:  reflect.TypeOf(widget{}),
+		ResponseType: reflect.TypeOf(widget{}),
+		RequestExamples: []types.Example{
+			{Name: "minimal", Value: widget{Name: "bolt"}},
+			{Name: "full", Summary: "Every field set", Value: widget{Name: "nut", Count: 3}},
+		},
+		ResponseExamples: []types.Example{{Name: "created", Value: widget{Name: "bolt", Count: 1}}},
+	}
+
+	operation := gen.buildOperation(route)
+	request := operation.RequestBody.Content["application/json"].Examples
+	assert.Equal(t, ExampleObject{Value: map[string]interface{}{"name": "bolt"}}, request["minimal"])
+	assert.Equal(t, ExampleObject{Summary: "Every field set", Value: map[string]interface{}{"name": "nut", "count": float64(3)}}, request["full"])
+	assert.Len(t, operation.Responses["200"].Content["application/json"].Examples, 1)
+	assert.Empty(t, operation.Responses["400"].Content["application/json"].Examples)
+
+	assert.NoError(t, validateExamples([]types.RouteInfo{route}))
+	route.RequestExamples = append(route.RequestExamples, types.Example{Name: "full"})
+	assert.ErrorContains(t, validateExamples([]types.RouteInfo{route}), `duplicate request example "full"`)
+	route.RequestExamples = []types.Example{{Name: "bad", Value: func() {}}}
+	assert.Error(t, validateExamples([]types.RouteInfo{route}))
+}
+
+func TestBuildResponses_RouteResponses(t *testing.T) {
+	type probe struct {
+		Status string `json:"status"`
+	}
+	gen := NewGenerator()
+
+	responses := gen.buildResponses(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/health/ready",
+		ResponseType: reflect.TypeOf(probe{}),
+		Responses: []types.Response{
+			{Status: 503, Description: "Not ready", Type: reflect.TypeOf(probe{})},
+			{Status: 409, Description: "Conflict"},
+		},
+	})
+
+	assert.Equal(t, "Not ready", responses["503"].Description)
+	assert.Equal(t, "#/components/schemas/probe", responses["503"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/ErrorResponse", responses["409"].Content["application/json"].Schema.Ref)
+}
+
+func TestBuildSpecDocument_Version(t *testing.T) {
+	gen := NewGenerator()
+	assert.NotEmpty(t, gen.buildSpecDocument("API", nil, nil).Info.Version)
+
+	gen.SetVersion("v1.2.3")
+	assert.Equal(t, "v1.2.3", gen.buildSpecDocument("API", nil, nil).Info.Version)
+}
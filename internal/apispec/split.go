@@ -0,0 +1,189 @@
+package apispec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+// SplitMode selects how the specification is divided into separate documents
+type SplitMode struct {
+	ByModule  bool // One document per RouteInfo.Module
+	ByVersion bool // One document per API version
+}
+
+// ParseSplitMode parses a comma-separated list such as "module,version"
+func ParseSplitMode(value string) (SplitMode, error) {
+	var mode SplitMode
+	for _, part := range strings.Split(value, ",") {
+		switch strings.TrimSpace(part) {
+		case "":
+		case "module":
+			mode.ByModule = true
+		case "version":
+			mode.ByVersion = true
+		default:
+			return SplitMode{}, fmt.Errorf("unknown split mode %q (expected module or version)", part)
+		}
+	}
+	return mode, nil
+}
+
+// SpecDocument is a single spec produced when splitting the route registry
+type SpecDocument struct {
+	Name  string      // File-safe name, e.g. module-health or version-v1
+	Title string      // Human-readable name shown in the docs spec selector
+	Spec  OpenAPISpec // The OpenAPI document for the subset of routes
+}
+
+// YAML renders the document in YAML format
+func (d SpecDocument) YAML() string {
+	return renderYAML(d.Spec)
+}
+
+// JSON renders the document in JSON format
+func (d SpecDocument) JSON() string {
+	return renderJSON(d.Spec)
+}
+
+// SpecIndexEntry describes one split document in the generated index file
+type SpecIndexEntry struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	JSON  string `json:"json"`
+	YAML  string `json:"yaml"`
+}
+
+// GenerateSplitSpecs generates one document per module and/or per version.
+// Routes without an API version are left out of the per-version documents.
+func (g *Generator) GenerateSplitSpecs(mode SplitMode) ([]SpecDocument, error) {
+	if err := g.prepare(); err != nil {
+		return nil, err
+	}
+
+	var docs []SpecDocument
+	if mode.ByModule {
+		groups := groupRoutes(g.routes, func(r types.RouteInfo) string { return r.Module })
+		for _, module := range sortedKeys(groups) {
+			docs = append(docs, g.buildSplitDocument("module-"+module, fmt.Sprintf("Module: %s", module), groups[module]))
+		}
+	}
+	if mode.ByVersion {
+		groups := groupRoutes(g.routes, types.RouteInfo.APIVersion)
+		for _, version := range sortedKeys(groups) {
+			docs = append(docs, g.buildSplitDocument("version-"+version, fmt.Sprintf("Version: %s", version), groups[version]))
+		}
+	}
+
+	return docs, nil
+}
+
+// BuildSpecIndex returns index entries for the given split documents
+func BuildSpecIndex(docs []SpecDocument) []SpecIndexEntry {
+	index := make([]SpecIndexEntry, 0, len(docs))
+	for _, doc := range docs {
+		index = append(index, SpecIndexEntry{
+			Name:  doc.Name,
+			Title: doc.Title,
+			JSON:  doc.Name + ".json",
+			YAML:  doc.Name + ".yaml",
+		})
+	}
+	return index
+}
+
+// buildSplitDocument builds a document containing only the schemas its routes reference
+func (g *Generator) buildSplitDocument(name, title string, routes []types.RouteInfo) SpecDocument {
+	schemas := map[string]interface{}{
+		"ErrorResponse": g.typeSchemas["ErrorResponse"],
+	}
+	for _, route := range routes {
+		if route.RequestType != nil {
+			typeName := g.getTypeName(route.RequestType)
+			schemas[typeName] = g.typeSchemas[typeName]
+		}
+		if route.ResponseType != nil {
+			typeName := g.getTypeName(route.ResponseType)
+			schemas[typeName] = g.typeSchemas[typeName]
+		}
+		for _, response := range route.Responses {
+			if response.Type != nil {
+				typeName := g.getTypeName(response.Type)
+				schemas[typeName] = g.typeSchemas[typeName]
+			}
+		}
+	}
+	g.addReferencedSchemas(schemas)
+
+	return SpecDocument{
+		Name:  sanitizeSpecName(name),
+		Title: title,
+		Spec:  g.buildSpecDocument(fmt.Sprintf("{{API_TITLE}} - %s", title), routes, schemas),
+	}
+}
+
+// addReferencedSchemas adds the component schemas that schemas reference,
+// directly or through other components
+func (g *Generator) addReferencedSchemas(schemas map[string]interface{}) {
+	var pending []interface{}
+	for _, schema := range schemas {
+		pending = append(pending, schema)
+	}
+	for len(pending) > 0 {
+		node := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		switch v := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := v["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/components/schemas/")
+				if _, seen := schemas[name]; !seen && g.typeSchemas[name] != nil {
+					schemas[name] = g.typeSchemas[name]
+					pending = append(pending, schemas[name])
+				}
+			}
+			for _, child := range v {
+				pending = append(pending, child)
+			}
+		case []interface{}:
+			pending = append(pending, v...)
+		}
+	}
+}
+
+// groupRoutes groups routes by key, skipping routes with an empty key
+func groupRoutes(routes []types.RouteInfo, key func(types.RouteInfo) string) map[string][]types.RouteInfo {
+	groups := make(map[string][]types.RouteInfo)
+	for _, route := range routes {
+		if k := key(route); k != "" {
+			groups[k] = append(groups[k], route)
+		}
+	}
+	return groups
+}
+
+// sortedKeys returns the keys of a route group map in sorted order
+func sortedKeys(groups map[string][]types.RouteInfo) []string {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sanitizeSpecName lowercases a name and replaces characters that are not
+// safe in file names or URLs
+func sanitizeSpecName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, name)
+}
@@ -0,0 +1,81 @@
+package apispec
+
+import (
+	"reflect"
+	"testing"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type splitTestResponse struct {
+	ID    string         `json:"id"`
+	Owner splitTestOwner `json:"owner"`
+}
+
+type splitTestOwner struct {
+	Name string `json:"name"`
+}
+
+func TestParseSplitMode(t *testing.T) {
+	mode, err := ParseSplitMode("module, version")
+	require.NoError(t, err)
+	assert.True(t, mode.ByModule)
+	assert.True(t, mode.ByVersion)
+
+	mode, err = ParseSplitMode("module")
+	require.NoError(t, err)
+	assert.True(t, mode.ByModule)
+	assert.False(t, mode.ByVersion)
+
+	_, err = ParseSplitMode("tag")
+	assert.Error(t, err)
+}
+
+func TestGenerateSplitSpecs(t *testing.T) {
+	types.ClearRegistry()
+	defer types.ClearRegistry()
+
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/health", Module: "health"})
+	types.RegisterRoute(types.RouteInfo{
+		Method:       "GET",
+		Path:         "/v1/users",
+		Module:       "users",
+		ResponseType: reflect.TypeOf(splitTestResponse{}),
+	})
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/users", Module: "users", Version: "v2"})
+
+	gen := NewGenerator()
+	docs, err := gen.GenerateSplitSpecs(SplitMode{ByModule: true, ByVersion: true})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		names = append(names, doc.Name)
+	}
+	assert.Equal(t, []string{"module-health", "module-users", "version-v1", "version-v2"}, names)
+
+	health := docs[0].Spec
+	assert.Contains(t, health.Paths, "/health")
+	assert.NotContains(t, health.Paths, "/v1/users")
+	assert.NotContains(t, health.Components.Schemas, "splitTestResponse")
+	assert.NotContains(t, health.Components.Schemas, "splitTestOwner")
+	assert.Contains(t, health.Components.Schemas, "ErrorResponse")
+
+	users := docs[1].Spec
+	assert.Len(t, users.Paths, 2)
+	assert.Contains(t, users.Components.Schemas, "splitTestResponse")
+	assert.Contains(t, users.Components.Schemas, "splitTestOwner", "nested schemas follow their referrers")
+
+	index := BuildSpecIndex(docs)
+	require.Len(t, index, 4)
+	assert.Equal(t, "module-health.json", index[0].JSON)
+	assert.Equal(t, "Module: health", index[0].Title)
+}
+
+func TestSanitizeSpecName(t *testing.T) {
+	assert.Equal(t, "module-my-module", sanitizeSpecName("module-My Module"))
+	assert.Equal(t, "version-v1", sanitizeSpecName("version-v1"))
+	assert.Equal(t, "module----etc", sanitizeSpecName("module-../etc"))
+}
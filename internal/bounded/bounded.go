@@ -0,0 +1,36 @@
+// Package bounded runs module code that may block or panic, such as health
+// checks and component start and stop functions, within a deadline.
+package bounded
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Run calls fn with ctx, bounded by timeout when it is positive. fn is
+// abandoned when ctx is done, and a panic in fn is returned as an error.
+func Run(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("did not complete: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,38 @@
+package bounded
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	assert.NoError(t, Run(context.Background(), time.Second, func(ctx context.Context) error { return nil }))
+
+	failed := errors.New("flush failed")
+	assert.ErrorIs(t, Run(context.Background(), 0, func(ctx context.Context) error { return failed }), failed)
+
+	err := Run(context.Background(), 0, func(ctx context.Context) error { panic("boom") })
+	assert.EqualError(t, err, "panic: boom")
+
+	// A function ignoring its context is abandoned at the deadline
+	block := make(chan struct{})
+	defer close(block)
+	err = Run(context.Background(), 10*time.Millisecond, func(ctx context.Context) error {
+		<-block
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "did not complete")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Run(cancelled, time.Second, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
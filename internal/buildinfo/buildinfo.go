@@ -0,0 +1,107 @@
+// Package buildinfo describes the running binary. Release builds inject the
+// version, commit and build time with the linker:
+//
+//	go build -ldflags "-X {{MODULE_NAME}}/internal/buildinfo.Version=v1.2.3 \
+//	  -X {{MODULE_NAME}}/internal/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X {{MODULE_NAME}}/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Values that are not injected fall back to what the Go toolchain records in
+// the binary (module version and VCS stamping).
+package buildinfo
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// Set with -ldflags "-X"; empty unless injected
+var (
+	Version   string
+	Commit    string
+	BuildTime string
+)
+
+// DevVersion is reported when no version was injected or recorded
+const DevVersion = "dev"
+
+// Info describes a build
+type Info struct {
+	Version   string
+	Commit    string
+	BuildTime string
+	GoVersion string
+	Modified  bool     // Built from a working tree with uncommitted changes
+	Modules   []Module // Dependencies compiled into the binary, sorted by path
+}
+
+// Module is a dependency compiled into the binary
+type Module struct {
+	Path    string
+	Version string
+}
+
+// readBuildInfo is replaced in tests
+var readBuildInfo = debug.ReadBuildInfo
+
+// Get returns the build information of the running binary
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+	}
+
+	if bi, ok := readBuildInfo(); ok {
+		info.GoVersion = bi.GoVersion
+		if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+		for _, dep := range bi.Deps {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			info.Modules = append(info.Modules, Module{Path: dep.Path, Version: dep.Version})
+		}
+		sort.Slice(info.Modules, func(i, j int) bool { return info.Modules[i].Path < info.Modules[j].Path })
+	}
+
+	if info.Version == "" {
+		info.Version = DevVersion
+	}
+	return info
+}
+
+// String summarises the build for startup logs
+func (i Info) String() string {
+	parts := []string{"version=" + i.Version}
+	if i.Commit != "" {
+		commit := i.Commit
+		if i.Modified {
+			commit += "-dirty"
+		}
+		parts = append(parts, "commit="+commit)
+	}
+	if i.BuildTime != "" {
+		parts = append(parts, "built="+i.BuildTime)
+	}
+	if i.GoVersion != "" {
+		parts = append(parts, "go="+i.GoVersion)
+	}
+	return fmt.Sprintf("%s (%d modules)", strings.Join(parts, " "), len(i.Modules))
+}
@@ -0,0 +1,77 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withBuildInfo replaces the toolchain build information for a test
+func withBuildInfo(t *testing.T, bi *debug.BuildInfo) {
+	original := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return bi, bi != nil }
+	t.Cleanup(func() { readBuildInfo = original })
+}
+
+// withInjected sets the linker variables for a test
+func withInjected(t *testing.T, version, commit, buildTime string) {
+	v, c, b := Version, Commit, BuildTime
+	Version, Commit, BuildTime = version, commit, buildTime
+	t.Cleanup(func() { Version, Commit, BuildTime = v, c, b })
+}
+
+func TestGet_FromToolchain(t *testing.T) {
+	withInjected(t, "", "", "")
+	withBuildInfo(t, &debug.BuildInfo{
+		GoVersion: "go1.24.3",
+		Main:      debug.Module{Path: "example.com/app", Version: "v1.4.0"},
+		Deps: []*debug.Module{
+			{Path: "go.uber.org/zap", Version: "v1.27.0"},
+			{Path: "github.com/spf13/viper", Version: "v1.20.1", Replace: &debug.Module{Path: "github.com/fork/viper", Version: "v1.20.2"}},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2025-06-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	})
+
+	info := Get()
+	assert.Equal(t, Info{
+		Version:   "v1.4.0",
+		Commit:    "abc123",
+		BuildTime: "2025-06-01T10:00:00Z",
+		GoVersion: "go1.24.3",
+		Modified:  true,
+		Modules: []Module{
+			{Path: "github.com/fork/viper", Version: "v1.20.2"},
+			{Path: "go.uber.org/zap", Version: "v1.27.0"},
+		},
+	}, info)
+	assert.Equal(t, "version=v1.4.0 commit=abc123-dirty built=2025-06-01T10:00:00Z go=go1.24.3 (2 modules)", info.String())
+}
+
+func TestGet_InjectedValuesWin(t *testing.T) {
+	withInjected(t, "v2.0.0", "def456", "2025-07-01T00:00:00Z")
+	withBuildInfo(t, &debug.BuildInfo{
+		GoVersion: "go1.24.3",
+		Main:      debug.Module{Version: "(devel)"},
+		Settings:  []debug.BuildSetting{{Key: "vcs.revision", Value: "abc123"}},
+	})
+
+	info := Get()
+	assert.Equal(t, "v2.0.0", info.Version)
+	assert.Equal(t, "def456", info.Commit)
+	assert.Equal(t, "2025-07-01T00:00:00Z", info.BuildTime)
+}
+
+func TestGet_Development(t *testing.T) {
+	withInjected(t, "", "", "")
+	withBuildInfo(t, &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}})
+	assert.Equal(t, DevVersion, Get().Version)
+
+	withBuildInfo(t, nil)
+	assert.Equal(t, Info{Version: DevVersion}, Get())
+	assert.Equal(t, "version=dev (0 modules)", Get().String())
+}
@@ -4,7 +4,9 @@ package config
 
 import (
 	"os" // Added for ToUpper
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,6 +14,65 @@ import (
 // Exported configuration keys
 const (
 	LogLevelKey = "log_level"
+
+	// Documentation UI keys
+	DocsEnabledKey  = "docs.enabled"
+	DocsBasePathKey = "docs.base_path"
+	DocsSpecURLKey  = "docs.spec_url"
+	// DocsLiveReloadKey lets the generator's -watch mode reload open docs pages
+	DocsLiveReloadKey = "docs.live_reload"
+
+	// OpenAPI contract validation keys
+	ValidationEnabledKey   = "validation.enabled"
+	ValidationSpecPathKey  = "validation.spec_path"
+	ValidationResponsesKey = "validation.responses"
+	ValidationStrictKey    = "validation.strict"
+
+	// Health check keys
+	HealthTimeoutKey  = "health.timeout"
+	HealthCacheTTLKey = "health.cache_ttl"
+
+	// Server keys
+	ServerHostKey = "server.host"
+	ServerPortKey = "server.port"
+	// ServerAddressKey is a host:port or unix:///path that overrides server.host and server.port
+	ServerAddressKey           = "server.address"
+	ServerUnixSocketModeKey    = "server.unix_socket_mode"
+	ServerUnixSocketGroupKey   = "server.unix_socket_group"
+	ServerReadTimeoutKey       = "server.read_timeout"
+	ServerReadHeaderTimeoutKey = "server.read_header_timeout"
+	ServerWriteTimeoutKey      = "server.write_timeout"
+	ServerIdleTimeoutKey       = "server.idle_timeout"
+	ServerMaxHeaderBytesKey    = "server.max_header_bytes"
+	ServerMaxBodyBytesKey      = "server.max_body_bytes"
+	ServerKeepAlivesKey        = "server.keep_alives"
+	ServerTCPKeepAliveKey      = "server.tcp_keep_alive"
+	// ServerWriteTimeoutsKey maps "METHOD /path" or "/path" to a write timeout override
+	ServerWriteTimeoutsKey   = "server.write_timeouts"
+	ServerDrainDelayKey      = "server.drain_delay"
+	ServerShutdownTimeoutKey = "server.shutdown_timeout"
+	ServerUpgradeTimeoutKey  = "server.upgrade_timeout"
+	// TLS keys; TLS is enabled when a certificate file is set
+	ServerTLSCertFileKey       = "server.tls.cert_file"
+	ServerTLSKeyFileKey        = "server.tls.key_file"
+	ServerTLSMinVersionKey     = "server.tls.min_version"
+	ServerTLSCipherPolicyKey   = "server.tls.cipher_policy"
+	ServerTLSClientCAFileKey   = "server.tls.client_ca_file"
+	ServerTLSClientAuthKey     = "server.tls.client_auth"
+	ServerTLSReloadIntervalKey = "server.tls.reload_interval"
+	// HTTP/2 keys; zero limits use Go's defaults
+	ServerHTTP2H2CKey                           = "server.http2.h2c"
+	ServerHTTP2MaxConcurrentStreamsKey          = "server.http2.max_concurrent_streams"
+	ServerHTTP2MaxReadFrameSizeKey              = "server.http2.max_read_frame_size"
+	ServerHTTP2MaxReceiveBufferPerConnectionKey = "server.http2.max_receive_buffer_per_connection"
+	ServerHTTP2MaxReceiveBufferPerStreamKey     = "server.http2.max_receive_buffer_per_stream"
+	// ServerAdminAddressKey is the host:port or unix:///path of the admin listener
+	ServerAdminAddressKey = "server.admin.address"
+	// ServerAdminPublicProbesKey keeps the health routes on the public listener
+	// when an admin listener is configured
+	ServerAdminPublicProbesKey = "server.admin.public_probes"
+	// LegacyServerPortKey is read when server.port is not set
+	LegacyServerPortKey = "server_port"
 )
 
 var (
@@ -38,8 +99,31 @@ func loadConfig() (*viper.Viper, error) {
 	if configPath != "" {
 		v.SetConfigFile(configPath)
 	}
+	// Nested keys such as docs.enabled map to DOCS_ENABLED in the environment
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
 	v.SetDefault(LogLevelKey, "INFO")
+	v.SetDefault(DocsEnabledKey, true)
+	v.SetDefault(DocsBasePathKey, "/docs")
+	v.SetDefault(DocsSpecURLKey, "/api/docs/openapi.json")
+	v.SetDefault(ValidationSpecPathKey, "docs/api/openapi.json")
+	v.SetDefault(HealthTimeoutKey, "2s")
+	v.SetDefault(HealthCacheTTLKey, "1s")
+	v.SetDefault(ServerReadTimeoutKey, "30s")
+	v.SetDefault(ServerReadHeaderTimeoutKey, "10s")
+	v.SetDefault(ServerWriteTimeoutKey, "30s")
+	v.SetDefault(ServerIdleTimeoutKey, "120s")
+	v.SetDefault(ServerMaxHeaderBytesKey, 1<<20)
+	v.SetDefault(ServerMaxBodyBytesKey, 10<<20)
+	v.SetDefault(ServerKeepAlivesKey, true)
+	v.SetDefault(ServerTCPKeepAliveKey, "15s")
+	v.SetDefault(ServerUnixSocketModeKey, "0660")
+	v.SetDefault(ServerDrainDelayKey, "5s")
+	v.SetDefault(ServerTLSMinVersionKey, "1.2")
+	v.SetDefault(ServerTLSCipherPolicyKey, "default")
+	v.SetDefault(ServerTLSReloadIntervalKey, "30s")
+	v.SetDefault(ServerShutdownTimeoutKey, "30s")
+	v.SetDefault(ServerUpgradeTimeoutKey, "30s")
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			// File not found: return viper instance with defaults
@@ -95,6 +179,15 @@ func GetInt(key string) int {
 	return config.GetInt(key)
 }
 
+// GetDuration returns a duration config value such as "500ms" or "2s".
+func GetDuration(key string) time.Duration {
+	_ = initConfig()
+	if config == nil {
+		return 0
+	}
+	return config.GetDuration(key)
+}
+
 // GetBool returns a bool config value.
 func GetBool(key string) bool {
 	_ = initConfig()
@@ -0,0 +1,342 @@
+// Package health runs the dependency checks that modules register and
+// aggregates them into liveness, readiness and startup reports.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"{{MODULE_NAME}}/internal/bounded"
+)
+
+// Status is the health of a single check or of a whole probe
+type Status string
+
+// Health statuses, from best to worst
+const (
+	StatusHealthy   Status = "HEALTHY"
+	StatusDegraded  Status = "DEGRADED"
+	StatusUnhealthy Status = "UNHEALTHY"
+)
+
+// Probe names the Kubernetes probe a check contributes to
+type Probe string
+
+// Probes served under /health/
+const (
+	// ProbeLive fails only when the process must be restarted
+	ProbeLive Probe = "live"
+	// ProbeReady fails while the instance should not receive traffic
+	ProbeReady Probe = "ready"
+	// ProbeStartup fails until the instance has finished starting
+	ProbeStartup Probe = "startup"
+)
+
+// HealthChecker reports whether a dependency is usable. A nil error means
+// healthy. Implementations should honour ctx, which carries the check's timeout.
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to HealthChecker
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Check is a named health check registered by a module
+type Check struct {
+	Name    string        // Unique name shown in reports, e.g. "database"
+	Checker HealthChecker // The check itself
+	// Critical checks make a probe UNHEALTHY when they fail; others only DEGRADED
+	Critical bool
+	// Timeout bounds a single run; the health.timeout config value when zero
+	Timeout time.Duration
+	// Probes lists the probes the check contributes to; ready and startup when empty.
+	// Only add ProbeLive for checks whose failure requires a restart.
+	Probes []Probe
+}
+
+// runsFor reports whether the check contributes to probe
+func (c Check) runsFor(probe Probe) bool {
+	if len(c.Probes) == 0 {
+		return probe == ProbeReady || probe == ProbeStartup
+	}
+	for _, p := range c.Probes {
+		if p == probe {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// registeredChecks holds the checks modules registered
+	registeredChecks []Check
+	// checksMutex protects registeredChecks
+	checksMutex sync.RWMutex
+)
+
+// Register adds a check to the global registry. Modules call it from init().
+// Registering a name again replaces the earlier check.
+func Register(check Check) {
+	checksMutex.Lock()
+	defer checksMutex.Unlock()
+
+	for i, existing := range registeredChecks {
+		if existing.Name == check.Name {
+			registeredChecks[i] = check
+			return
+		}
+	}
+	registeredChecks = append(registeredChecks, check)
+}
+
+// RegisteredChecks returns a copy of the registered checks sorted by name
+func RegisteredChecks() []Check {
+	checksMutex.RLock()
+	defer checksMutex.RUnlock()
+
+	checks := make([]Check, len(registeredChecks))
+	copy(checks, registeredChecks)
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return checks
+}
+
+// ClearChecks removes every registered check (used for testing)
+func ClearChecks() {
+	checksMutex.Lock()
+	defer checksMutex.Unlock()
+
+	registeredChecks = nil
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name      string
+	Status    Status
+	Critical  bool
+	Error     string
+	Duration  time.Duration
+	CheckedAt time.Time
+	Cached    bool
+}
+
+// Report is the aggregate outcome of a probe
+type Report struct {
+	Status Status
+	Checks []Result
+}
+
+// Options configures a Runner
+type Options struct {
+	// Checks returns the checks to run; RegisteredChecks when nil
+	Checks func() []Check
+	// Timeout applies to checks without their own timeout
+	Timeout time.Duration
+	// CacheTTL is how long a result is reused before the check runs again
+	CacheTTL time.Duration
+}
+
+// Runner executes checks concurrently, caching their results
+type Runner struct {
+	opts Options
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	started bool
+	unready map[string]readinessMark
+}
+
+// readinessMark is a readiness failure added by MarkUnready or MarkDegraded
+type readinessMark struct {
+	reason   string
+	critical bool
+}
+
+// cacheEntry holds the latest result of one check. Its mutex is held while
+// the check runs so concurrent probes share a single execution.
+type cacheEntry struct {
+	mu     sync.Mutex
+	result Result
+	valid  bool
+}
+
+// NewRunner creates a check runner
+func NewRunner(opts Options) *Runner {
+	if opts.Checks == nil {
+		opts.Checks = RegisteredChecks
+	}
+	return &Runner{opts: opts, entries: make(map[string]*cacheEntry), unready: make(map[string]readinessMark)}
+}
+
+// MarkUnready fails the readiness probe with reason until MarkReady is called
+// with the same name. The server uses it to stop traffic while it drains.
+func (r *Runner) MarkUnready(name, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.unready[name] = readinessMark{reason: reason, critical: true}
+}
+
+// MarkDegraded reports readiness as DEGRADED with reason until MarkReady is
+// called with the same name, without taking the instance out of traffic
+func (r *Runner) MarkDegraded(name, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.unready[name] = readinessMark{reason: reason}
+}
+
+// MarkReady removes a readiness failure added by MarkUnready or MarkDegraded
+func (r *Runner) MarkReady(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.unready, name)
+}
+
+// unreadyResults returns the MarkUnready and MarkDegraded failures as results
+func (r *Runner) unreadyResults() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]Result, 0, len(r.unready))
+	for name, mark := range r.unready {
+		status := StatusDegraded
+		if mark.critical {
+			status = StatusUnhealthy
+		}
+		results = append(results, Result{
+			Name:      name,
+			Status:    status,
+			Critical:  mark.critical,
+			Error:     mark.reason,
+			CheckedAt: time.Now(),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+// Run executes the checks of a probe and aggregates them. Once a startup
+// probe has passed, later startup probes pass without running checks.
+func (r *Runner) Run(ctx context.Context, probe Probe) Report {
+	if probe == ProbeStartup {
+		r.mu.Lock()
+		started := r.started
+		r.mu.Unlock()
+		if started {
+			return Report{Status: StatusHealthy, Checks: []Result{}}
+		}
+	}
+
+	var checks []Check
+	for _, check := range r.opts.Checks() {
+		if check.runsFor(probe) {
+			checks = append(checks, check)
+		}
+	}
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = r.result(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	if probe == ProbeReady {
+		results = append(results, r.unreadyResults()...)
+	}
+
+	report := Report{Status: Aggregate(results), Checks: results}
+	if probe == ProbeStartup && report.Status != StatusUnhealthy {
+		r.mu.Lock()
+		r.started = true
+		r.mu.Unlock()
+	}
+	return report
+}
+
+// Aggregate returns UNHEALTHY if a critical check failed, DEGRADED if any
+// other check failed, and HEALTHY otherwise
+func Aggregate(results []Result) Status {
+	status := StatusHealthy
+	for _, result := range results {
+		if result.Status == StatusHealthy {
+			continue
+		}
+		if result.Critical {
+			return StatusUnhealthy
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+// result returns the cached result of a check or runs it
+func (r *Runner) result(ctx context.Context, check Check) Result {
+	r.mu.Lock()
+	entry, ok := r.entries[check.Name]
+	if !ok {
+		entry = &cacheEntry{}
+		r.entries[check.Name] = entry
+	}
+	r.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.valid && time.Since(entry.result.CheckedAt) < r.opts.CacheTTL {
+		cached := entry.result
+		cached.Cached = true
+		return cached
+	}
+
+	result := r.execute(ctx, check)
+	if ctx.Err() != nil && result.Status != StatusHealthy {
+		// The caller gave up; its cancellation says nothing about the dependency
+		return result
+	}
+	entry.result = result
+	entry.valid = true
+	return entry.result
+}
+
+// execute runs a check with its timeout. A check that ignores its context
+// is abandoned when the timeout expires. Checks with a timeout run detached
+// from the caller's cancellation so a disconnecting probe client cannot fail
+// the result other callers share.
+func (r *Runner) execute(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = r.opts.Timeout
+	}
+	if timeout > 0 {
+		ctx = context.WithoutCancel(ctx)
+	}
+
+	start := time.Now()
+	err := bounded.Run(ctx, timeout, check.Checker.Check)
+
+	result := Result{
+		Name:      check.Name,
+		Status:    StatusHealthy,
+		Critical:  check.Critical,
+		Duration:  time.Since(start),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticChecks returns a Checks option serving checks
+func staticChecks(checks ...Check) func() []Check {
+	return func() []Check { return checks }
+}
+
+func TestRunner_Aggregation(t *testing.T) {
+	ok := CheckerFunc(func(ctx context.Context) error { return nil })
+	failing := CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+
+	tests := []struct {
+		name     string
+		checks   []Check
+		expected Status
+	}{
+		{"no checks", nil, StatusHealthy},
+		{"all passing", []Check{{Name: "db", Checker: ok, Critical: true}}, StatusHealthy},
+		{"optional failure", []Check{{Name: "db", Checker: ok, Critical: true}, {Name: "cache", Checker: failing}}, StatusDegraded},
+		{"critical failure", []Check{{Name: "db", Checker: failing, Critical: true}, {Name: "cache", Checker: failing}}, StatusUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewRunner(Options{Checks: staticChecks(tt.checks...)}).Run(context.Background(), ProbeReady)
+			assert.Equal(t, tt.expected, report.Status)
+			assert.Len(t, report.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestRunner_ResultDetails(t *testing.T) {
+	runner := NewRunner(Options{Checks: staticChecks(
+		Check{Name: "db", Critical: true, Checker: CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") })},
+		Check{Name: "panics", Checker: CheckerFunc(func(ctx context.Context) error { panic("boom") })},
+	)})
+
+	report := runner.Run(context.Background(), ProbeReady)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, Result{Name: "db", Status: StatusUnhealthy, Critical: true, Error: "connection refused"},
+		Result{Name: report.Checks[0].Name, Status: report.Checks[0].Status, Critical: report.Checks[0].Critical, Error: report.Checks[0].Error})
+	assert.Equal(t, "panic: boom", report.Checks[1].Error)
+	assert.False(t, report.Checks[1].Critical)
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	runner := NewRunner(Options{
+		Timeout: 20 * time.Millisecond,
+		Checks: staticChecks(
+			Check{Name: "honours-context", Critical: true, Checker: CheckerFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})},
+			Check{Name: "ignores-context", Timeout: 10 * time.Millisecond, Checker: CheckerFunc(func(ctx context.Context) error {
+				<-release
+				return nil
+			})},
+		),
+	})
+
+	start := time.Now()
+	report := runner.Run(context.Background(), ProbeReady)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Contains(t, report.Checks[0].Error, "deadline exceeded")
+	assert.Contains(t, report.Checks[1].Error, "did not complete")
+}
+
+func TestRunner_RunsChecksConcurrently(t *testing.T) {
+	slow := CheckerFunc(func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	var checks []Check
+	for _, name := range []string{"a", "b", "c", "d"} {
+		checks = append(checks, Check{Name: name, Checker: slow})
+	}
+
+	start := time.Now()
+	NewRunner(Options{Checks: staticChecks(checks...)}).Run(context.Background(), ProbeReady)
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestRunner_Cache(t *testing.T) {
+	var calls atomic.Int32
+	check := Check{Name: "db", Checker: CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})}
+
+	runner := NewRunner(Options{Checks: staticChecks(check), CacheTTL: time.Hour})
+	assert.False(t, runner.Run(context.Background(), ProbeReady).Checks[0].Cached)
+	assert.True(t, runner.Run(context.Background(), ProbeReady).Checks[0].Cached)
+	assert.Equal(t, int32(1), calls.Load())
+
+	runner = NewRunner(Options{Checks: staticChecks(check)})
+	runner.Run(context.Background(), ProbeReady)
+	runner.Run(context.Background(), ProbeReady)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRunner_CallerCancellation(t *testing.T) {
+	honours := CheckerFunc(func(ctx context.Context) error {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// With a timeout the check runs detached from the caller
+	runner := NewRunner(Options{Checks: staticChecks(Check{Name: "db", Checker: honours}), Timeout: time.Second, CacheTTL: time.Hour})
+	assert.Equal(t, StatusHealthy, runner.Run(cancelled, ProbeReady).Status)
+
+	// Without one the caller's cancellation fails the run but is not cached
+	runner = NewRunner(Options{Checks: staticChecks(Check{Name: "db", Checker: honours}), CacheTTL: time.Hour})
+	assert.Equal(t, StatusDegraded, runner.Run(cancelled, ProbeReady).Status)
+	report := runner.Run(context.Background(), ProbeReady)
+	assert.Equal(t, StatusHealthy, report.Status)
+	assert.False(t, report.Checks[0].Cached)
+}
+
+func TestRunner_Probes(t *testing.T) {
+	var ready atomic.Bool
+	checks := staticChecks(
+		Check{Name: "process", Critical: true, Probes: []Probe{ProbeLive}, Checker: CheckerFunc(func(ctx context.Context) error { return nil })},
+		Check{Name: "db", Critical: true, Checker: CheckerFunc(func(ctx context.Context) error {
+			if !ready.Load() {
+				return errors.New("not connected")
+			}
+			return nil
+		})},
+	)
+	runner := NewRunner(Options{Checks: checks})
+
+	live := runner.Run(context.Background(), ProbeLive)
+	assert.Equal(t, StatusHealthy, live.Status)
+	require.Len(t, live.Checks, 1)
+	assert.Equal(t, "process", live.Checks[0].Name)
+
+	assert.Equal(t, StatusUnhealthy, runner.Run(context.Background(), ProbeStartup).Status)
+	ready.Store(true)
+	assert.Equal(t, StatusHealthy, runner.Run(context.Background(), ProbeStartup).Status)
+
+	// Startup stays passed once it succeeded; readiness follows the checks
+	ready.Store(false)
+	assert.Equal(t, StatusHealthy, runner.Run(context.Background(), ProbeStartup).Status)
+	assert.Equal(t, StatusUnhealthy, runner.Run(context.Background(), ProbeReady).Status)
+}
+
+func TestRegister(t *testing.T) {
+	ClearChecks()
+	defer ClearChecks()
+
+	Register(Check{Name: "db"})
+	Register(Check{Name: "cache"})
+	Register(Check{Name: "db", Critical: true})
+
+	checks := RegisteredChecks()
+	require.Len(t, checks, 2)
+	assert.Equal(t, "cache", checks[0].Name)
+	assert.Equal(t, "db", checks[1].Name)
+	assert.True(t, checks[1].Critical)
+}
+
+func TestRunner_MarkUnready(t *testing.T) {
+	runner := NewRunner(Options{Checks: staticChecks()})
+
+	runner.MarkUnready("shutdown", "server is draining")
+	report := runner.Run(context.Background(), ProbeReady)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "server is draining", report.Checks[0].Error)
+	assert.Equal(t, StatusHealthy, runner.Run(context.Background(), ProbeLive).Status)
+
+	runner.MarkReady("shutdown")
+	assert.Equal(t, StatusHealthy, runner.Run(context.Background(), ProbeReady).Status)
+}
+
+func TestRunner_MarkDegraded(t *testing.T) {
+	runner := NewRunner(Options{Checks: staticChecks()})
+
+	runner.MarkDegraded("component:warmer", "cache warmer stopped")
+	report := runner.Run(context.Background(), ProbeReady)
+	assert.Equal(t, StatusDegraded, report.Status)
+	require.Len(t, report.Checks, 1)
+	assert.False(t, report.Checks[0].Critical)
+	assert.Equal(t, "cache warmer stopped", report.Checks[0].Error)
+
+	runner.MarkReady("component:warmer")
+	assert.Equal(t, StatusHealthy, runner.Run(context.Background(), ProbeReady).Status)
+}
@@ -0,0 +1,359 @@
+// Package lifecycle starts and stops the background components modules
+// register, such as cache warmers, consumers and schedulers, together with
+// the server.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"{{MODULE_NAME}}/internal/bounded"
+	"{{MODULE_NAME}}/internal/health"
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// Default timeouts for components that do not set their own
+const (
+	DefaultStartTimeout = 30 * time.Second
+	DefaultStopTimeout  = 10 * time.Second
+)
+
+// Component is a background part of the server. Start returns once the
+// component runs, leaving long-running work in goroutines bound to ctx,
+// which stays open until the component has stopped. Stop should return
+// when its ctx is done.
+type Component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Funcs adapts a pair of functions to Component; nil functions do nothing
+type Funcs struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Start calls OnStart
+func (f Funcs) Start(ctx context.Context) error {
+	if f.OnStart == nil {
+		return nil
+	}
+	return f.OnStart(ctx)
+}
+
+// Stop calls OnStop
+func (f Funcs) Stop(ctx context.Context) error {
+	if f.OnStop == nil {
+		return nil
+	}
+	return f.OnStop(ctx)
+}
+
+// Registration is a named component registered by a module
+type Registration struct {
+	Name      string    // Unique name shown in logs and readiness reports
+	Component Component // The component itself
+	// DependsOn names components that start before and stop after this one
+	DependsOn []string
+	// Critical components shut the server down when they fail; others only
+	// make readiness DEGRADED
+	Critical     bool
+	StartTimeout time.Duration // DefaultStartTimeout when zero
+	StopTimeout  time.Duration // DefaultStopTimeout when zero
+}
+
+var (
+	// registrations holds components in registration order
+	registrations []Registration
+	// registrationsMutex protects registrations
+	registrationsMutex sync.RWMutex
+)
+
+// Register adds a component started with the server. Modules call it from init().
+func Register(registration Registration) {
+	registrationsMutex.Lock()
+	defer registrationsMutex.Unlock()
+
+	registrations = append(registrations, registration)
+}
+
+// Registered returns a copy of the registered components in registration order
+func Registered() []Registration {
+	registrationsMutex.RLock()
+	defer registrationsMutex.RUnlock()
+
+	result := make([]Registration, len(registrations))
+	copy(result, registrations)
+	return result
+}
+
+// Clear removes every registered component (used for testing)
+func Clear() {
+	registrationsMutex.Lock()
+	defer registrationsMutex.Unlock()
+
+	registrations = nil
+}
+
+// Options configures a Manager
+type Options struct {
+	// Components to manage; Registered() when nil
+	Components []Registration
+	// Readiness reflects component failures; optional
+	Readiness *health.Runner
+}
+
+// Manager starts components in dependency order and stops them in reverse
+type Manager struct {
+	opts  Options
+	order []Registration
+
+	mu       sync.Mutex
+	started  []*running
+	stopping bool
+	failed   chan error
+}
+
+// running is a started component and the context it runs under
+type running struct {
+	registration Registration
+	ctx          context.Context
+	cancel       context.CancelFunc
+}
+
+// New checks the components' names and dependencies and orders them
+func New(opts Options) (*Manager, error) {
+	if opts.Components == nil {
+		opts.Components = Registered()
+	}
+	order, err := sortByDependencies(opts.Components)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{opts: opts, order: order, failed: make(chan error, 1)}, nil
+}
+
+// Start starts every component in dependency order. A component whose
+// dependency did not start is skipped. When a critical component fails to
+// start, the components already running are stopped and the error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	skipped := make(map[string]error)
+	for _, registration := range m.order {
+		err := dependencyError(registration, skipped)
+		if err == nil {
+			err = m.start(ctx, registration)
+		}
+		if err == nil {
+			continue
+		}
+
+		skipped[registration.Name] = err
+		if registration.Critical {
+			err = fmt.Errorf("component %s: %w", registration.Name, err)
+			if stopErr := m.Stop(ctx); stopErr != nil {
+				err = errors.Join(err, stopErr)
+			}
+			return err
+		}
+		logging.Warn("Component %s did not start: %v", registration.Name, err)
+		m.markFailed(registration, err)
+	}
+	return nil
+}
+
+// dependencyError returns an error when a dependency of registration was skipped
+func dependencyError(registration Registration, skipped map[string]error) error {
+	for _, dependency := range registration.DependsOn {
+		if _, ok := skipped[dependency]; ok {
+			return fmt.Errorf("dependency %s did not start", dependency)
+		}
+	}
+	return nil
+}
+
+// start runs a component's Start with its timeout and records it as running
+func (m *Manager) start(ctx context.Context, registration Registration) error {
+	timeout := registration.StartTimeout
+	if timeout <= 0 {
+		timeout = DefaultStartTimeout
+	}
+
+	r := &running{registration: registration}
+	r.ctx, r.cancel = context.WithCancel(context.WithValue(context.Background(), componentKey{}, &reporter{manager: m, registration: registration}))
+
+	logging.Info("Starting component %s", registration.Name)
+	start := time.Now()
+	// The component keeps r.ctx; the start timeout only bounds the call
+	err := bounded.Run(ctx, timeout, func(context.Context) error { return registration.Component.Start(r.ctx) })
+	if err != nil {
+		r.cancel()
+		return err
+	}
+	logging.Debug("Component %s started in %s", registration.Name, time.Since(start).Round(time.Millisecond))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = append(m.started, r)
+	return nil
+}
+
+// Stop stops the running components in reverse start order, each with its
+// own deadline, and cancels their contexts. Failures reported while
+// stopping are ignored. Stop may be called more than once.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	m.stopping = true
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		r := started[i]
+		timeout := r.registration.StopTimeout
+		if timeout <= 0 {
+			timeout = DefaultStopTimeout
+		}
+
+		logging.Info("Stopping component %s", r.registration.Name)
+		err := bounded.Run(ctx, timeout, r.registration.Component.Stop)
+		r.cancel()
+		if err != nil {
+			logging.Error("Component %s did not stop cleanly: %v", r.registration.Name, err)
+			errs = append(errs, fmt.Errorf("component %s: %w", r.registration.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Failed receives the first failure of a critical component after Start.
+// The server shuts down when it does.
+func (m *Manager) Failed() <-chan error {
+	return m.failed
+}
+
+// fail handles a failure reported by a running component
+func (m *Manager) fail(registration Registration, err error) {
+	m.mu.Lock()
+	stopping := m.stopping
+	m.mu.Unlock()
+	if stopping {
+		logging.Debug("Ignoring failure of component %s during shutdown: %v", registration.Name, err)
+		return
+	}
+
+	m.markFailed(registration, err)
+	if !registration.Critical {
+		logging.Warn("Component %s failed: %v", registration.Name, err)
+		return
+	}
+	logging.Error("Critical component %s failed: %v", registration.Name, err)
+	select {
+	case m.failed <- fmt.Errorf("component %s: %w", registration.Name, err):
+	default:
+	}
+}
+
+// markFailed reflects a component failure in readiness
+func (m *Manager) markFailed(registration Registration, err error) {
+	if m.opts.Readiness == nil {
+		return
+	}
+	name := ReadinessName(registration.Name)
+	if registration.Critical {
+		m.opts.Readiness.MarkUnready(name, err.Error())
+	} else {
+		m.opts.Readiness.MarkDegraded(name, err.Error())
+	}
+}
+
+// ReadinessName is the name under which a component's failure appears in
+// readiness reports
+func ReadinessName(component string) string {
+	return "component:" + component
+}
+
+// componentKey is the context key of the reporter passed to Start
+type componentKey struct{}
+
+// reporter lets a running component report failures to its manager
+type reporter struct {
+	manager      *Manager
+	registration Registration
+}
+
+// Fail reports that the component started with ctx has failed. A critical
+// component's failure shuts the server down; any failure shows in readiness.
+// Call it with the ctx passed to Start or one derived from it.
+func Fail(ctx context.Context, err error) {
+	r, ok := ctx.Value(componentKey{}).(*reporter)
+	if !ok {
+		logging.Error("lifecycle.Fail called outside a component: %v", err)
+		return
+	}
+	r.manager.fail(r.registration, err)
+}
+
+// sortByDependencies orders components so that each follows its
+// dependencies, keeping registration order otherwise
+func sortByDependencies(components []Registration) ([]Registration, error) {
+	byName := make(map[string]Registration, len(components))
+	var errs []error
+	for _, c := range components {
+		if c.Name == "" || c.Component == nil {
+			errs = append(errs, fmt.Errorf("component %q: name and component are required", c.Name))
+			continue
+		}
+		if _, exists := byName[c.Name]; exists {
+			errs = append(errs, fmt.Errorf("component %s: registered twice", c.Name))
+			continue
+		}
+		byName[c.Name] = c
+	}
+	for _, c := range components {
+		for _, dependency := range c.DependsOn {
+			if _, ok := byName[dependency]; !ok {
+				errs = append(errs, fmt.Errorf("component %s: unknown dependency %s", c.Name, dependency))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(components))
+	order := make([]Registration, 0, len(components))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+		state[name] = visiting
+		for _, dependency := range byName[name].DependsOn {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, byName[name])
+		return nil
+	}
+	for _, c := range components {
+		if err := visit(c.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
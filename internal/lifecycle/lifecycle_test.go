@@ -0,0 +1,251 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"{{MODULE_NAME}}/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records component events in order
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// component returns a registration that records its start and stop
+func (r *recorder) component(name string, dependsOn ...string) Registration {
+	return Registration{
+		Name:      name,
+		DependsOn: dependsOn,
+		Component: Funcs{
+			OnStart: func(ctx context.Context) error { r.add("start " + name); return nil },
+			OnStop:  func(ctx context.Context) error { r.add("stop " + name); return nil },
+		},
+	}
+}
+
+// newReadiness returns a health runner without checks
+func newReadiness() *health.Runner {
+	return health.NewRunner(health.Options{Checks: func() []health.Check { return nil }})
+}
+
+func TestManager_DependencyOrder(t *testing.T) {
+	events := &recorder{}
+	manager, err := New(Options{Components: []Registration{
+		events.component("scheduler", "cache", "queue"),
+		events.component("cache"),
+		events.component("queue", "cache"),
+	}})
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Start(context.Background()))
+	require.NoError(t, manager.Stop(context.Background()))
+	require.NoError(t, manager.Stop(context.Background()))
+
+	assert.Equal(t, []string{
+		"start cache", "start queue", "start scheduler",
+		"stop scheduler", "stop queue", "stop cache",
+	}, events.list())
+}
+
+func TestNew_InvalidRegistrations(t *testing.T) {
+	events := &recorder{}
+
+	_, err := New(Options{Components: []Registration{
+		events.component("cache"),
+		events.component("cache"),
+		events.component("queue", "broker"),
+		{Name: "empty"},
+	}})
+	require.Error(t, err)
+	for _, msg := range []string{
+		"component cache: registered twice",
+		"component queue: unknown dependency broker",
+		`component "empty": name and component are required`,
+	} {
+		assert.Contains(t, err.Error(), msg)
+	}
+
+	_, err = New(Options{Components: []Registration{
+		events.component("a", "c"),
+		events.component("b", "a"),
+		events.component("c", "b"),
+	}})
+	assert.EqualError(t, err, "dependency cycle: a -> c -> b -> a")
+}
+
+func TestManager_CriticalStartFailure(t *testing.T) {
+	events := &recorder{}
+	failing := events.component("consumer", "cache")
+	failing.Critical = true
+	failing.Component = Funcs{OnStart: func(ctx context.Context) error { return errors.New("broker unreachable") }}
+
+	manager, err := New(Options{Components: []Registration{events.component("cache"), failing, events.component("scheduler")}})
+	require.NoError(t, err)
+
+	err = manager.Start(context.Background())
+	assert.EqualError(t, err, "component consumer: broker unreachable")
+	assert.Equal(t, []string{"start cache", "stop cache"}, events.list())
+}
+
+func TestManager_NonCriticalStartFailure(t *testing.T) {
+	events := &recorder{}
+	failing := events.component("warmer")
+	failing.Component = Funcs{OnStart: func(ctx context.Context) error { panic("bad cache key") }}
+	readiness := newReadiness()
+
+	manager, err := New(Options{
+		Components: []Registration{failing, events.component("reporter", "warmer"), events.component("scheduler")},
+		Readiness:  readiness,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Start(context.Background()))
+	assert.Equal(t, []string{"start scheduler"}, events.list())
+
+	report := readiness.Run(context.Background(), health.ProbeReady)
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, ReadinessName("reporter"), report.Checks[0].Name)
+	assert.Equal(t, "dependency warmer did not start", report.Checks[0].Error)
+	assert.Equal(t, ReadinessName("warmer"), report.Checks[1].Name)
+	assert.Equal(t, "panic: bad cache key", report.Checks[1].Error)
+}
+
+func TestManager_StartTimeout(t *testing.T) {
+	manager, err := New(Options{Components: []Registration{{
+		Name:         "slow",
+		Critical:     true,
+		StartTimeout: 50 * time.Millisecond,
+		Component: Funcs{OnStart: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	}}})
+	require.NoError(t, err)
+
+	err = manager.Start(context.Background())
+	assert.ErrorContains(t, err, "component slow: did not complete: context deadline exceeded")
+}
+
+func TestFail_CriticalComponentTriggersShutdown(t *testing.T) {
+	readiness := newReadiness()
+	stopped := make(chan struct{})
+	manager, err := New(Options{
+		Components: []Registration{{
+			Name:     "consumer",
+			Critical: true,
+			Component: Funcs{OnStart: func(ctx context.Context) error {
+				go func() {
+					Fail(ctx, errors.New("subscription lost"))
+					<-ctx.Done()
+					close(stopped)
+				}()
+				return nil
+			}},
+		}},
+		Readiness: readiness,
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.Start(context.Background()))
+
+	select {
+	case err := <-manager.Failed():
+		assert.EqualError(t, err, "component consumer: subscription lost")
+	case <-time.After(5 * time.Second):
+		t.Fatal("critical failure was not reported")
+	}
+	report := readiness.Run(context.Background(), health.ProbeReady)
+	assert.Equal(t, health.StatusUnhealthy, report.Status)
+	assert.Equal(t, ReadinessName("consumer"), report.Checks[0].Name)
+
+	// Stopping cancels the context the component runs under
+	require.NoError(t, manager.Stop(context.Background()))
+	<-stopped
+}
+
+func TestFail_IgnoredWhileStopping(t *testing.T) {
+	var runCtx context.Context
+	manager, err := New(Options{Components: []Registration{{
+		Name:     "consumer",
+		Critical: true,
+		Component: Funcs{
+			OnStart: func(ctx context.Context) error { runCtx = ctx; return nil },
+			OnStop: func(ctx context.Context) error {
+				Fail(runCtx, errors.New("connection closed"))
+				return errors.New("did not flush")
+			},
+		},
+	}}})
+	require.NoError(t, err)
+	require.NoError(t, manager.Start(context.Background()))
+
+	assert.EqualError(t, manager.Stop(context.Background()), "component consumer: did not flush")
+	select {
+	case err := <-manager.Failed():
+		t.Fatalf("unexpected failure: %v", err)
+	default:
+	}
+}
+
+func TestManager_StopFailuresDoNotStopOthers(t *testing.T) {
+	events := &recorder{}
+	stopOnly := func(name string, timeout time.Duration, stop func(ctx context.Context) error) Registration {
+		return Registration{Name: name, StopTimeout: timeout, Component: Funcs{OnStop: func(ctx context.Context) error {
+			events.add("stop " + name)
+			return stop(ctx)
+		}}}
+	}
+	manager, err := New(Options{Components: []Registration{
+		stopOnly("database", 0, func(ctx context.Context) error { return nil }),
+		stopOnly("slow", 20*time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		stopOnly("failing", 0, func(ctx context.Context) error { return errors.New("flush failed") }),
+		stopOnly("panicking", 0, func(ctx context.Context) error { panic("boom") }),
+	}})
+	require.NoError(t, err)
+	require.NoError(t, manager.Start(context.Background()))
+
+	err = manager.Stop(context.Background())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "component panicking: panic: boom")
+	assert.ErrorContains(t, err, "component failing: flush failed")
+	assert.ErrorContains(t, err, "component slow: ")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"stop panicking", "stop failing", "stop slow", "stop database"}, events.list())
+}
+
+func TestRegister(t *testing.T) {
+	Clear()
+	defer Clear()
+	events := &recorder{}
+	Register(events.component("cache"))
+	Register(events.component("queue", "cache"))
+	assert.Len(t, Registered(), 2)
+
+	manager, err := New(Options{})
+	require.NoError(t, err)
+	require.NoError(t, manager.Start(context.Background()))
+	require.NoError(t, manager.Stop(context.Background()))
+	assert.Equal(t, []string{"start cache", "start queue", "stop queue", "stop cache"}, events.list())
+}
@@ -0,0 +1,193 @@
+// Package mock serves synthesized responses for every operation in an
+// OpenAPI document so clients can be developed before handlers exist.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/logging"
+	"{{MODULE_NAME}}/internal/openapi"
+)
+
+// Request headers that control mock responses
+const (
+	// StatusHeader forces the response status code, e.g. "404"
+	StatusHeader = "X-Mock-Status"
+	// DelayHeader delays the response, e.g. "250ms" or "2s"; bare numbers are milliseconds
+	DelayHeader = "X-Mock-Delay"
+)
+
+// errorResponseRef is the standard error schema; it is filled in with the
+// real status rather than synthesized
+const errorResponseRef = "#/components/schemas/ErrorResponse"
+
+// MaxDelay caps the latency a client can request through DelayHeader
+const MaxDelay = 30 * time.Second
+
+// Options configures the mock handler
+type Options struct {
+	// PassthroughTags lists operation tags served by Next instead of the mock,
+	// e.g. "docs" so the documentation UI keeps working in mock mode
+	PassthroughTags []string
+	// Next serves passthrough operations; required when PassthroughTags is set
+	Next http.Handler
+}
+
+// Handler serves mock responses for the operations in an OpenAPI document
+type Handler struct {
+	doc  *openapi.Document
+	opts Options
+}
+
+// NewHandler creates a mock handler for doc
+func NewHandler(doc *openapi.Document, opts Options) *Handler {
+	return &Handler{doc: doc, opts: opts}
+}
+
+// ServeHTTP responds with a synthesized body for the documented operation
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	match := h.doc.FindOperation(r.Method, r.URL.Path)
+	if match == nil {
+		types.WriteError(w, http.StatusNotFound, fmt.Sprintf("No mock operation for %s", r.URL.Path))
+		return
+	}
+	if match.Operation == nil {
+		types.WriteError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s is not documented for %s", r.Method, match.Template))
+		return
+	}
+	if h.passthrough(match.Operation) {
+		h.opts.Next.ServeHTTP(w, r)
+		return
+	}
+
+	if delay, err := requestedDelay(r); err != nil {
+		types.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	status, err := h.selectStatus(r, match.Operation)
+	if err != nil {
+		types.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logging.Debug("Mock %s %s -> %d", r.Method, match.Template, status)
+
+	response, documented := match.Operation.Responses[strconv.Itoa(status)]
+	content, hasJSON := response.Content["application/json"]
+	if hasJSON && content.Schema != nil && content.Schema.Ref == errorResponseRef {
+		types.WriteError(w, status, http.StatusText(status))
+		return
+	}
+	if !documented || !hasJSON {
+		if status >= http.StatusBadRequest {
+			types.WriteError(w, status, http.StatusText(status))
+			return
+		}
+		w.WriteHeader(status)
+		return
+	}
+
+	body := h.responseBody(r, match.Template, content)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logging.Error("Failed to encode mock response: %v", err)
+	}
+}
+
+// passthrough reports whether the operation should be served by Next
+func (h *Handler) passthrough(op *openapi.Operation) bool {
+	if h.opts.Next == nil {
+		return false
+	}
+	for _, tag := range op.Tags {
+		for _, pt := range h.opts.PassthroughTags {
+			if tag == pt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectStatus returns the forced status, or the lowest documented 2xx status
+func (h *Handler) selectStatus(r *http.Request, op *openapi.Operation) (int, error) {
+	if forced := r.Header.Get(StatusHeader); forced != "" {
+		status, err := strconv.Atoi(forced)
+		if err != nil || status < 100 || status > 599 {
+			return 0, fmt.Errorf("invalid %s header %q", StatusHeader, forced)
+		}
+		return status, nil
+	}
+
+	var codes []int
+	for code := range op.Responses {
+		if n, err := strconv.Atoi(code); err == nil && n >= 200 && n < 300 {
+			codes = append(codes, n)
+		}
+	}
+	if len(codes) == 0 {
+		return http.StatusOK, nil
+	}
+	sort.Ints(codes)
+	return codes[0], nil
+}
+
+// responseBody returns the documented example or a synthesized value. The
+// synthesizer is seeded from the request line so repeated calls are stable.
+func (h *Handler) responseBody(r *http.Request, template string, content openapi.MediaType) interface{} {
+	if content.Example != nil {
+		return content.Example
+	}
+	if len(content.Examples) > 0 {
+		names := make([]string, 0, len(content.Examples))
+		for name := range content.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return content.Examples[names[0]].Value
+	}
+
+	seed := fnv.New64a()
+	seed.Write([]byte(r.Method + " " + r.URL.Path))
+	return NewSynthesizer(h.doc, int64(seed.Sum64())).Value(content.Schema)
+}
+
+// requestedDelay parses DelayHeader, capped at MaxDelay
+func requestedDelay(r *http.Request) (time.Duration, error) {
+	raw := strings.TrimSpace(r.Header.Get(DelayHeader))
+	if raw == "" {
+		return 0, nil
+	}
+
+	delay, err := time.ParseDuration(raw)
+	if err != nil {
+		ms, convErr := strconv.Atoi(raw)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid %s header %q", DelayHeader, raw)
+		}
+		delay = time.Duration(ms) * time.Millisecond
+	}
+	if delay < 0 {
+		return 0, fmt.Errorf("invalid %s header %q", DelayHeader, raw)
+	}
+	if delay > MaxDelay {
+		delay = MaxDelay
+	}
+	return delay, nil
+}
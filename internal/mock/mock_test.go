@@ -0,0 +1,161 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"{{MODULE_NAME}}/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      tags: [users]
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        "404":
+          description: Not Found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ErrorResponse'
+  /docs:
+    get:
+      tags: [docs]
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, name, email, created, roles, age]
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+        email:
+          type: string
+        nickname:
+          type: string
+          example: Countess
+        created:
+          type: string
+          format: date-time
+        age:
+          type: integer
+          minimum: 18
+          maximum: 99
+        roles:
+          type: array
+          items:
+            type: string
+            enum: [admin, member]
+    ErrorResponse:
+      type: object
+      required: [error, message, status]
+      properties:
+        error:
+          type: boolean
+        message:
+          type: string
+        status:
+          type: integer
+`
+
+func newTestHandler(t *testing.T, opts Options) *Handler {
+	doc, err := openapi.ParseYAML([]byte(testSpec))
+	require.NoError(t, err)
+	return NewHandler(doc, opts)
+}
+
+func get(h http.Handler, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_SynthesizesValidResponse(t *testing.T) {
+	h := newTestHandler(t, Options{})
+
+	w := get(h, "/users/42", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Empty(t, h.doc.Validate(&openapi.Schema{Ref: "#/components/schemas/User"}, body))
+	assert.Equal(t, "Countess", body["nickname"])
+	assert.Contains(t, body["email"], "@example.com")
+
+	// Responses are stable for the same request
+	again := get(h, "/users/42", nil)
+	assert.Equal(t, w.Body.String(), again.Body.String())
+}
+
+func TestHandler_ForcedStatus(t *testing.T) {
+	h := newTestHandler(t, Options{})
+
+	w := get(h, "/users/42", map[string]string{StatusHeader: "404"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error": true, "message": "Not Found", "status": 404}`, w.Body.String())
+
+	w = get(h, "/users/42", map[string]string{StatusHeader: "503"})
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = get(h, "/users/42", map[string]string{StatusHeader: "abc"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_Delay(t *testing.T) {
+	h := newTestHandler(t, Options{})
+
+	start := time.Now()
+	w := get(h, "/users/42", map[string]string{DelayHeader: "50ms"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	w = get(h, "/users/42", map[string]string{DelayHeader: "soon"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_UnknownRoutes(t *testing.T) {
+	h := newTestHandler(t, Options{})
+
+	assert.Equal(t, http.StatusNotFound, get(h, "/missing", nil).Code)
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/1", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestHandler_Passthrough(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	h := newTestHandler(t, Options{PassthroughTags: []string{"docs"}, Next: next})
+
+	assert.Equal(t, http.StatusTeapot, get(h, "/docs", nil).Code)
+	assert.Equal(t, http.StatusOK, get(h, "/users/1", nil).Code)
+}
@@ -0,0 +1,229 @@
+// Package openapi loads OpenAPI 3.0 documents, such as the one produced by
+// cmd/generate-openapi, and validates values against their schemas.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the subset of an OpenAPI 3.0 document used at runtime
+type Document struct {
+	OpenAPI    string              `json:"openapi" yaml:"openapi"`
+	Info       Info                `json:"info" yaml:"info"`
+	Paths      map[string]PathItem `json:"paths" yaml:"paths"`
+	Components Components          `json:"components" yaml:"components"`
+}
+
+// Info contains API metadata
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// PathItem describes the operations available on a single path
+type PathItem struct {
+	Parameters []Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Get        *Operation  `json:"get,omitempty" yaml:"get,omitempty"`
+	Post       *Operation  `json:"post,omitempty" yaml:"post,omitempty"`
+	Put        *Operation  `json:"put,omitempty" yaml:"put,omitempty"`
+	Patch      *Operation  `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Delete     *Operation  `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// Operation returns the operation for an HTTP method, or nil if none is documented
+func (p PathItem) Operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD":
+		return p.Get
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "PATCH":
+		return p.Patch
+	case "DELETE":
+		return p.Delete
+	}
+	return nil
+}
+
+// Operations returns the documented operations keyed by upper-case method
+func (p PathItem) Operations() map[string]*Operation {
+	ops := make(map[string]*Operation)
+	for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+		if op := p.Operation(method); op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+// Operation describes a single API operation
+type Operation struct {
+	Tags        []string            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	OperationID string              `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses" yaml:"responses"`
+}
+
+// Parameter describes a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name" yaml:"name"`
+	In          string  `json:"in" yaml:"in"`
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// RequestBody describes an operation's request body
+type RequestBody struct {
+	Description string               `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty" yaml:"required,omitempty"`
+	Content     map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// Response describes a single response
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// MediaType provides the schema and examples for a content type
+type MediaType struct {
+	Schema   *Schema            `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example  interface{}        `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples map[string]Example `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+// Example is a named example value
+type Example struct {
+	Summary string      `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Value   interface{} `json:"value" yaml:"value"`
+}
+
+// Components holds reusable schemas
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas" yaml:"schemas"`
+}
+
+// LoadFile reads a document from a .json, .yaml or .yml file
+func LoadFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document %s: %w", path, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSON(data)
+	}
+	return ParseYAML(data)
+}
+
+// ParseJSON parses a JSON encoded document
+func ParseJSON(data []byte) (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI JSON: %w", err)
+	}
+	return &doc, nil
+}
+
+// ParseYAML parses a YAML encoded document
+func ParseYAML(data []byte) (*Document, error) {
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI YAML: %w", err)
+	}
+	return &doc, nil
+}
+
+// ResolveSchema follows local $ref pointers until it reaches a concrete schema.
+// It returns nil if a reference cannot be resolved.
+func (d *Document) ResolveSchema(s *Schema) *Schema {
+	for hops := 0; s != nil && s.Ref != ""; hops++ {
+		if hops > 32 {
+			return nil
+		}
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		if name == s.Ref {
+			return nil
+		}
+		s = d.Components.Schemas[name]
+	}
+	return s
+}
+
+// SortedPaths returns the document's path templates in sorted order
+func (d *Document) SortedPaths() []string {
+	paths := make([]string, 0, len(d.Paths))
+	for p := range d.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// DefaultModule groups operations without tags
+const DefaultModule = "default"
+
+// PathOperation is a documented operation with its path and the parameters
+// it accepts
+type PathOperation struct {
+	Method     string
+	Path       string
+	Operation  *Operation
+	Parameters []Parameter // Path-level parameters merged with the operation's
+}
+
+// Module returns the operation's first tag, or DefaultModule
+func (o PathOperation) Module() string {
+	if len(o.Operation.Tags) > 0 && o.Operation.Tags[0] != "" {
+		return o.Operation.Tags[0]
+	}
+	return DefaultModule
+}
+
+// SortedOperations returns every operation in path order, sorted by method
+// within a path
+func (d *Document) SortedOperations() []PathOperation {
+	var ops []PathOperation
+	for _, path := range d.SortedPaths() {
+		item := d.Paths[path]
+		byMethod := item.Operations()
+		methods := make([]string, 0, len(byMethod))
+		for method := range byMethod {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			op := byMethod[method]
+			ops = append(ops, PathOperation{
+				Method:     method,
+				Path:       path,
+				Operation:  op,
+				Parameters: MergeParameters(item.Parameters, op.Parameters),
+			})
+		}
+	}
+	return ops
+}
+
+// GroupByModule groups operations by Module, keeping their order
+func GroupByModule(ops []PathOperation) map[string][]PathOperation {
+	modules := make(map[string][]PathOperation)
+	for _, o := range ops {
+		modules[o.Module()] = append(modules[o.Module()], o)
+	}
+	return modules
+}
@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"strings"
+)
+
+// Match is the documented operation that serves a request path
+type Match struct {
+	Template   string            // Path template from the document, e.g. /users/{id}
+	PathItem   PathItem          // The path item containing the operation
+	Operation  *Operation        // The operation, nil if the method is not documented
+	PathParams map[string]string // Values captured from templated segments
+}
+
+// FindOperation locates the path template and operation serving method and path.
+// Templates ending in "/" match whole subtrees like http.ServeMux patterns.
+// Literal segments are preferred over templated ones. It returns nil if no
+// documented path matches.
+func (d *Document) FindOperation(method, path string) *Match {
+	var best *Match
+	bestScore := -1
+
+	for _, template := range d.SortedPaths() {
+		params, score, ok := matchTemplate(template, path)
+		if !ok || score <= bestScore {
+			continue
+		}
+		item := d.Paths[template]
+		best = &Match{
+			Template:   template,
+			PathItem:   item,
+			Operation:  item.Operation(method),
+			PathParams: params,
+		}
+		bestScore = score
+	}
+
+	return best
+}
+
+// matchTemplate matches path against template and scores the match by the
+// number of literal characters matched, so the most specific template wins.
+// A trailing {name...} segment captures the rest of the path, like it does in
+// http.ServeMux patterns.
+func matchTemplate(template, path string) (map[string]string, int, bool) {
+	if strings.HasSuffix(template, "/") {
+		if strings.HasPrefix(path, template) {
+			return map[string]string{}, len(template), true
+		}
+		return nil, 0, false
+	}
+
+	tSegs := strings.Split(strings.Trim(template, "/"), "/")
+	pSegs := strings.Split(strings.Trim(path, "/"), "/")
+	params := make(map[string]string)
+	score := 1 // exact-length matches beat subtree and wildcard matches of equal length
+
+	if last := tSegs[len(tSegs)-1]; strings.HasPrefix(last, "{") && strings.HasSuffix(last, "...}") {
+		tSegs = tSegs[:len(tSegs)-1]
+		if len(pSegs) < len(tSegs) {
+			return nil, 0, false
+		}
+		rest := strings.Join(pSegs[len(tSegs):], "/")
+		if rest != "" && strings.HasSuffix(path, "/") {
+			rest += "/"
+		}
+		params[last[1:len(last)-4]] = rest
+		pSegs = pSegs[:len(tSegs)]
+		score = 0
+	} else if len(tSegs) != len(pSegs) {
+		return nil, 0, false
+	}
+
+	for i, seg := range tSegs {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if pSegs[i] == "" {
+				return nil, 0, false
+			}
+			params[seg[1:len(seg)-1]] = pSegs[i]
+			continue
+		}
+		if seg != pSegs[i] {
+			return nil, 0, false
+		}
+		score += len(seg) + 1
+	}
+
+	return params, score, true
+}
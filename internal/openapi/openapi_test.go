@@ -0,0 +1,275 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUsers
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      parameters:
+        - name: verbose
+          in: query
+          schema:
+            type: boolean
+      responses:
+        "200":
+          description: Success
+  /users/me:
+    get:
+      responses:
+        "200":
+          description: Success
+  /assets/:
+    get:
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    User:
+      type: object
+      required: [name, tags]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+        created:
+          type: string
+          format: date-time
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+func loadTestDocument(t *testing.T) *Document {
+	doc, err := ParseYAML([]byte(testSpec))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSpec), 0644))
+
+	doc, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Test API", doc.Info.Title)
+	assert.Len(t, doc.Paths, 4)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	doc := loadTestDocument(t)
+	user := &Schema{Ref: "#/components/schemas/User"}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		pointers []string
+	}{
+		{
+			name:  "valid",
+			value: map[string]interface{}{"name": "a", "age": float64(3), "tags": []interface{}{"x"}},
+		},
+		{
+			name:     "missing required",
+			value:    map[string]interface{}{"name": "a"},
+			pointers: []string{"/tags"},
+		},
+		{
+			name:     "wrong types",
+			value:    map[string]interface{}{"name": float64(1), "age": 1.5, "tags": []interface{}{true}},
+			pointers: []string{"/age", "/name", "/tags/0"},
+		},
+		{
+			name:     "bad date-time",
+			value:    map[string]interface{}{"name": "a", "tags": []interface{}{}, "created": "yesterday"},
+			pointers: []string{"/created"},
+		},
+		{
+			name:     "not an object",
+			value:    "user",
+			pointers: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := doc.Validate(user, tt.value)
+			pointers := make([]string, 0, len(errs))
+			for _, e := range errs {
+				pointers = append(pointers, e.Pointer)
+			}
+			assert.ElementsMatch(t, tt.pointers, pointers)
+		})
+	}
+}
+
+func TestFindOperation(t *testing.T) {
+	doc := loadTestDocument(t)
+
+	match := doc.FindOperation("GET", "/users/42")
+	require.NotNil(t, match)
+	assert.Equal(t, "/users/{id}", match.Template)
+	assert.Equal(t, "42", match.PathParams["id"])
+
+	match = doc.FindOperation("GET", "/users/me")
+	require.NotNil(t, match)
+	assert.Equal(t, "/users/me", match.Template)
+
+	match = doc.FindOperation("GET", "/assets/js/app.js")
+	require.NotNil(t, match)
+	assert.Equal(t, "/assets/", match.Template)
+
+	match = doc.FindOperation("DELETE", "/users")
+	require.NotNil(t, match)
+	assert.Nil(t, match.Operation)
+
+	assert.Nil(t, doc.FindOperation("GET", "/unknown"))
+}
+
+func TestFindOperation_Wildcard(t *testing.T) {
+	doc := &Document{Paths: map[string]PathItem{
+		"/files/{path...}":           {Get: &Operation{}},
+		"/files/readme":              {Get: &Operation{}},
+		"/buckets/{bucket}/{key...}": {Get: &Operation{}},
+	}}
+
+	match := doc.FindOperation("GET", "/files/docs/a b/c.txt")
+	require.NotNil(t, match)
+	assert.Equal(t, "/files/{path...}", match.Template)
+	assert.Equal(t, map[string]string{"path": "docs/a b/c.txt"}, match.PathParams)
+
+	match = doc.FindOperation("GET", "/files/")
+	require.NotNil(t, match)
+	assert.Equal(t, "", match.PathParams["path"])
+
+	match = doc.FindOperation("GET", "/files/readme")
+	require.NotNil(t, match)
+	assert.Equal(t, "/files/readme", match.Template, "literal routes win over wildcards")
+
+	match = doc.FindOperation("GET", "/buckets/logs/2024/01/")
+	require.NotNil(t, match)
+	assert.Equal(t, map[string]string{"bucket": "logs", "key": "2024/01/"}, match.PathParams)
+
+	assert.Nil(t, doc.FindOperation("GET", "/buckets"))
+}
+
+func TestValidateRequest(t *testing.T) {
+	doc := loadTestDocument(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"valid body", "POST", "/users", `{"name": "a", "tags": []}`, 0},
+		{"missing body", "POST", "/users", ``, http.StatusBadRequest},
+		{"malformed body", "POST", "/users", `{"name":`, http.StatusBadRequest},
+		{"schema violation", "POST", "/users", `{"name": 1, "tags": []}`, http.StatusUnprocessableEntity},
+		{"valid params", "GET", "/users/7?verbose=true", ``, 0},
+		{"bad path param", "GET", "/users/abc", ``, http.StatusBadRequest},
+		{"bad query param", "GET", "/users/7?verbose=maybe", ``, http.StatusBadRequest},
+		{"undocumented method", "DELETE", "/users", ``, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+
+			match, violations := doc.ValidateRequest(r, []byte(tt.body))
+			require.NotNil(t, match)
+			if tt.status == 0 {
+				assert.Empty(t, violations)
+				return
+			}
+			require.NotEmpty(t, violations)
+			assert.Equal(t, tt.status, violations[0].Status)
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	doc := loadTestDocument(t)
+	op := doc.Paths["/users"].Post
+	header := http.Header{"Content-Type": []string{"application/json"}}
+
+	errs := doc.ValidateResponse(op, 200, header, []byte(`{"name": "a", "tags": null}`))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "/tags", errs[0].Pointer)
+
+	assert.Empty(t, doc.ValidateResponse(op, 200, header, []byte(`{"name": "a", "tags": ["x"]}`)))
+
+	errs = doc.ValidateResponse(op, 200, header, []byte(`{"tags": []}`))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "/name", errs[0].Pointer)
+
+	errs = doc.ValidateResponse(op, 404, header, nil)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "404")
+}
+
+func TestSortedOperations(t *testing.T) {
+	limit := Parameter{Name: "limit", In: "query"}
+	doc := &Document{Paths: map[string]PathItem{
+		"/users/{id}": {
+			Parameters: []Parameter{{Name: "id", In: "path"}, {Name: "verbose", In: "query"}},
+			Get:        &Operation{Tags: []string{"users"}, Parameters: []Parameter{{Name: "verbose", In: "query", Required: true}}},
+			Delete:     &Operation{Tags: []string{"users"}},
+		},
+		"/health": {Get: &Operation{Parameters: []Parameter{limit}}},
+	}}
+
+	ops := doc.SortedOperations()
+	require.Len(t, ops, 3)
+	assert.Equal(t, []string{"GET /health", "DELETE /users/{id}", "GET /users/{id}"},
+		[]string{ops[0].Method + " " + ops[0].Path, ops[1].Method + " " + ops[1].Path, ops[2].Method + " " + ops[2].Path})
+	assert.Equal(t, []Parameter{{Name: "id", In: "path"}, {Name: "verbose", In: "query", Required: true}}, ops[2].Parameters)
+
+	modules := GroupByModule(ops)
+	assert.Equal(t, []PathOperation{ops[0]}, modules[DefaultModule])
+	assert.Len(t, modules["users"], 2)
+}
@@ -0,0 +1,249 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RequestViolation is a request validation failure with the status code it maps to
+type RequestViolation struct {
+	ValidationError
+	Status int // 400 for malformed input, 405 for undocumented methods, 422 for schema violations
+}
+
+// ValidateRequest checks the parameters and body of r against the documented
+// operation. body is the already-read request body. It returns a nil match
+// when the path is not documented.
+func (d *Document) ValidateRequest(r *http.Request, body []byte) (*Match, []RequestViolation) {
+	match := d.FindOperation(r.Method, r.URL.Path)
+	if match == nil {
+		return nil, nil
+	}
+	return match, d.ValidateMatchedRequest(match, r, body)
+}
+
+// ValidateMatchedRequest checks r against an operation FindOperation already
+// located. Callers only need to read the body when the operation declares one.
+func (d *Document) ValidateMatchedRequest(match *Match, r *http.Request, body []byte) []RequestViolation {
+	if match.Operation == nil {
+		return []RequestViolation{{
+			ValidationError: ValidationError{Message: fmt.Sprintf("method %s is not documented for %s", r.Method, match.Template)},
+			Status:          http.StatusMethodNotAllowed,
+		}}
+	}
+
+	var violations []RequestViolation
+	for _, param := range MergeParameters(match.PathItem.Parameters, match.Operation.Parameters) {
+		violations = append(violations, d.validateParameter(param, r, match.PathParams)...)
+	}
+	violations = append(violations, d.validateRequestBody(match.Operation.RequestBody, r.Header.Get("Content-Type"), body)...)
+
+	return violations
+}
+
+// ValidateResponse checks a response status and body against the operation's
+// documented responses
+func (d *Document) ValidateResponse(op *Operation, status int, header http.Header, body []byte) []ValidationError {
+	response, ok := findResponse(op.Responses, status)
+	if !ok {
+		return []ValidationError{{Message: fmt.Sprintf("status %d is not a documented response", status)}}
+	}
+	if len(response.Content) == 0 {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	content, ok := response.Content[mediaType]
+	if !ok {
+		if len(body) == 0 {
+			return nil
+		}
+		return []ValidationError{{Message: fmt.Sprintf("content type %q is not documented for status %d", mediaType, status)}}
+	}
+	if content.Schema == nil || !IsJSONMediaType(mediaType) {
+		return nil
+	}
+
+	value, err := decodeJSON(body)
+	if err != nil {
+		return []ValidationError{{Message: fmt.Sprintf("response body is not valid JSON: %v", err)}}
+	}
+	return d.Validate(content.Schema, value)
+}
+
+// validateParameter checks a single path, query or header parameter
+func (d *Document) validateParameter(param Parameter, r *http.Request, pathParams map[string]string) []RequestViolation {
+	var raw []string
+	switch param.In {
+	case "path":
+		if v, ok := pathParams[param.Name]; ok {
+			raw = []string{v}
+		}
+	case "query":
+		raw = r.URL.Query()[param.Name]
+	case "header":
+		raw = r.Header.Values(param.Name)
+	default:
+		return nil
+	}
+
+	pointer := "/" + param.In + "/" + escapePointer(param.Name)
+	if len(raw) == 0 {
+		if param.Required || param.In == "path" {
+			return []RequestViolation{{ValidationError{pointer, "required parameter is missing"}, http.StatusBadRequest}}
+		}
+		return nil
+	}
+	if param.Schema == nil {
+		return nil
+	}
+
+	value, err := coerceParameter(d.ResolveSchema(param.Schema), raw)
+	if err != nil {
+		return []RequestViolation{{ValidationError{pointer, err.Error()}, http.StatusBadRequest}}
+	}
+
+	var violations []RequestViolation
+	for _, e := range d.Validate(param.Schema, value) {
+		e.Pointer = pointer + e.Pointer
+		violations = append(violations, RequestViolation{e, http.StatusBadRequest})
+	}
+	return violations
+}
+
+// validateRequestBody checks the request body's presence, content type and schema
+func (d *Document) validateRequestBody(rb *RequestBody, contentType string, body []byte) []RequestViolation {
+	if rb == nil {
+		return nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if rb.Required {
+			return []RequestViolation{{ValidationError{Message: "request body is required"}, http.StatusBadRequest}}
+		}
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return []RequestViolation{{ValidationError{Message: "missing or invalid Content-Type header"}, http.StatusBadRequest}}
+	}
+	content, ok := rb.Content[mediaType]
+	if !ok {
+		return []RequestViolation{{ValidationError{Message: fmt.Sprintf("content type %q is not accepted", mediaType)}, http.StatusBadRequest}}
+	}
+	if content.Schema == nil || !IsJSONMediaType(mediaType) {
+		return nil
+	}
+
+	value, err := decodeJSON(body)
+	if err != nil {
+		return []RequestViolation{{ValidationError{Message: fmt.Sprintf("request body is not valid JSON: %v", err)}, http.StatusBadRequest}}
+	}
+
+	var violations []RequestViolation
+	for _, e := range d.Validate(content.Schema, value) {
+		violations = append(violations, RequestViolation{e, http.StatusUnprocessableEntity})
+	}
+	return violations
+}
+
+// MergeParameters combines path-level and operation-level parameters;
+// operation parameters override path parameters with the same name and location
+func MergeParameters(pathParams, opParams []Parameter) []Parameter {
+	merged := make([]Parameter, 0, len(pathParams)+len(opParams))
+	for _, p := range pathParams {
+		overridden := false
+		for _, o := range opParams {
+			if o.Name == p.Name && o.In == p.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, p)
+		}
+	}
+	return append(merged, opParams...)
+}
+
+// coerceParameter converts raw string parameter values to the schema's type
+func coerceParameter(schema *Schema, raw []string) (interface{}, error) {
+	if schema == nil {
+		return raw[0], nil
+	}
+	if schema.Type == "array" {
+		items := schema.Items
+		values := make([]interface{}, 0, len(raw))
+		for _, r := range raw {
+			for _, part := range strings.Split(r, ",") {
+				v, err := coerceScalar(items, part)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+		}
+		return values, nil
+	}
+	return coerceScalar(schema, raw[0])
+}
+
+// coerceScalar converts a single raw value to the schema's scalar type
+func coerceScalar(schema *Schema, raw string) (interface{}, error) {
+	if schema == nil {
+		return raw, nil
+	}
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected integer, got %q", raw)
+		}
+		return float64(n), nil
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected number, got %q", raw)
+		}
+		return n, nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected boolean, got %q", raw)
+		}
+		return b, nil
+	}
+	return raw, nil
+}
+
+// findResponse looks up a response by exact status, then range (2XX), then default
+func findResponse(responses map[string]Response, status int) (Response, bool) {
+	code := strconv.Itoa(status)
+	if r, ok := responses[code]; ok {
+		return r, true
+	}
+	if r, ok := responses[code[:1]+"XX"]; ok {
+		return r, true
+	}
+	r, ok := responses["default"]
+	return r, ok
+}
+
+// IsJSONMediaType reports whether a media type carries JSON
+func IsJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// decodeJSON decodes a JSON document into generic values
+func decodeJSON(body []byte) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
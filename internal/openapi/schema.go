@@ -0,0 +1,309 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of the OpenAPI schema object supported for validation
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Description          string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Example              interface{}        `json:"example,omitempty" yaml:"example,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+}
+
+// ValidationError describes a single schema violation
+type ValidationError struct {
+	Pointer string // JSON pointer to the offending value ("" is the root)
+	Message string
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s: %s", pointer, e.Message)
+}
+
+// maxValidationDepth bounds recursion through self-referencing schemas
+const maxValidationDepth = 64
+
+// Validate checks a decoded JSON value against a schema and returns every violation
+func (d *Document) Validate(schema *Schema, value interface{}) []ValidationError {
+	var errs []ValidationError
+	d.validate(schema, value, "", 0, &errs)
+	return errs
+}
+
+// validate appends violations for value at pointer to errs
+func (d *Document) validate(schema *Schema, value interface{}, pointer string, depth int, errs *[]ValidationError) {
+	if depth > maxValidationDepth {
+		return
+	}
+
+	resolved := d.ResolveSchema(schema)
+	if resolved == nil {
+		if schema != nil {
+			*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("unresolvable schema reference %q", schema.Ref)})
+		}
+		return
+	}
+	schema = resolved
+
+	for _, sub := range schema.AllOf {
+		d.validate(sub, value, pointer, depth+1, errs)
+	}
+	if len(schema.AnyOf) > 0 && d.countMatches(schema.AnyOf, value, depth) == 0 {
+		*errs = append(*errs, ValidationError{pointer, "value does not match any of the allowed schemas"})
+	}
+	if len(schema.OneOf) > 0 {
+		if n := d.countMatches(schema.OneOf, value, depth); n != 1 {
+			*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("value matches %d schemas, expected exactly one", n)})
+		}
+	}
+
+	if value == nil {
+		if !schema.Nullable && schema.Type != "" {
+			*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("expected %s, got null", schema.Type)})
+		}
+		return
+	}
+
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("value %v is not one of the allowed values", value)})
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			*errs = append(*errs, typeError(pointer, "object", value))
+			return
+		}
+		d.validateObject(schema, obj, pointer, depth, errs)
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			*errs = append(*errs, typeError(pointer, "array", value))
+			return
+		}
+		for i, item := range arr {
+			if schema.Items != nil {
+				d.validate(schema.Items, item, fmt.Sprintf("%s/%d", pointer, i), depth+1, errs)
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			*errs = append(*errs, typeError(pointer, "string", value))
+			return
+		}
+		validateString(schema, s, pointer, errs)
+	case "integer":
+		n, ok := toFloat(value)
+		if !ok || n != math.Trunc(n) {
+			*errs = append(*errs, typeError(pointer, "integer", value))
+			return
+		}
+		validateRange(schema, n, pointer, errs)
+	case "number":
+		n, ok := toFloat(value)
+		if !ok {
+			*errs = append(*errs, typeError(pointer, "number", value))
+			return
+		}
+		validateRange(schema, n, pointer, errs)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*errs = append(*errs, typeError(pointer, "boolean", value))
+		}
+	}
+}
+
+// validateObject checks required, declared and additional properties
+func (d *Document) validateObject(schema *Schema, obj map[string]interface{}, pointer string, depth int, errs *[]ValidationError) {
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			*errs = append(*errs, ValidationError{pointer + "/" + escapePointer(name), "required property is missing"})
+		}
+	}
+
+	for _, name := range sortedKeys(obj) {
+		child := pointer + "/" + escapePointer(name)
+		if prop, ok := schema.Properties[name]; ok {
+			d.validate(prop, obj[name], child, depth+1, errs)
+			continue
+		}
+		switch extra := schema.AdditionalProperties.(type) {
+		case bool:
+			if !extra {
+				*errs = append(*errs, ValidationError{child, "additional property is not allowed"})
+			}
+		case map[string]interface{}:
+			if extraSchema := schemaFromMap(extra); extraSchema != nil {
+				d.validate(extraSchema, obj[name], child, depth+1, errs)
+			}
+		}
+	}
+}
+
+// countMatches returns how many of the schemas accept value
+func (d *Document) countMatches(schemas []*Schema, value interface{}, depth int) int {
+	matches := 0
+	for _, sub := range schemas {
+		var subErrs []ValidationError
+		d.validate(sub, value, "", depth+1, &subErrs)
+		if len(subErrs) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// validateString checks string length, pattern and well-known formats
+func validateString(schema *Schema, s, pointer string, errs *[]ValidationError) {
+	length := len([]rune(s))
+	if schema.MinLength != nil && length < *schema.MinLength {
+		*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("string is shorter than %d characters", *schema.MinLength)})
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("string is longer than %d characters", *schema.MaxLength)})
+	}
+	if schema.Pattern != "" {
+		if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(s) {
+			*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("string does not match pattern %q", schema.Pattern)})
+		}
+	}
+	switch schema.Format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			*errs = append(*errs, ValidationError{pointer, "string is not an RFC 3339 date-time"})
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			*errs = append(*errs, ValidationError{pointer, "string is not a full-date"})
+		}
+	}
+}
+
+// validateRange checks numeric minimum and maximum
+func validateRange(schema *Schema, n float64, pointer string, errs *[]ValidationError) {
+	if schema.Minimum != nil && n < *schema.Minimum {
+		*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("value is less than minimum %v", *schema.Minimum)})
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		*errs = append(*errs, ValidationError{pointer, fmt.Sprintf("value is greater than maximum %v", *schema.Maximum)})
+	}
+}
+
+// typeError reports a value of the wrong JSON type
+func typeError(pointer, expected string, value interface{}) ValidationError {
+	return ValidationError{pointer, fmt.Sprintf("expected %s, got %s", expected, jsonTypeName(value))}
+}
+
+// jsonTypeName names the JSON type of a decoded value
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if _, ok := toFloat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toFloat converts the numeric types produced by JSON and YAML decoding
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// enumContains reports whether value equals one of the enum entries
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+		a, aok := toFloat(allowed)
+		v, vok := toFloat(value)
+		if aok && vok && a == v {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaFromMap converts an inline schema decoded as a generic map
+func schemaFromMap(m map[string]interface{}) *Schema {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+	return &s
+}
+
+// escapePointer escapes a property name for use in a JSON pointer (RFC 6901)
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// sortedKeys returns the keys of an object in sorted order for stable output
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
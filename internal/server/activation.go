@@ -0,0 +1,167 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// Environment variables of the systemd socket activation protocol
+const (
+	listenPIDEnv     = "LISTEN_PID"
+	listenFDsEnv     = "LISTEN_FDS"
+	listenFDNamesEnv = "LISTEN_FDNAMES"
+	// listenFDsStart is the first inherited file descriptor
+	listenFDsStart = 3
+)
+
+// AdminSocketName is the FileDescriptorName= of an inherited socket that
+// serves admin-only routes. The first socket with any other name is public.
+const AdminSocketName = "admin"
+
+// inheritedListeners are the listeners passed in by the service manager or
+// by the process that started an upgrade
+type inheritedListeners struct {
+	public net.Listener
+	admin  net.Listener
+}
+
+var (
+	// activationOnce reads the activation environment on first use
+	activationOnce sync.Once
+	// activationMutex protects activated and activationErr
+	activationMutex sync.Mutex
+	activated       inheritedListeners
+	activationErr   error
+)
+
+// loadActivation collects the inherited sockets once and clears the
+// activation variables so child processes do not pick them up
+func loadActivation() {
+	activationOnce.Do(func() {
+		files, names, err := activationFiles(os.Getenv, os.Getpid())
+		for _, key := range []string{listenPIDEnv, listenFDsEnv, listenFDNamesEnv} {
+			os.Unsetenv(key)
+		}
+
+		var inherited inheritedListeners
+		if err == nil && len(files) > 0 {
+			inherited, err = newInheritedListeners(files, names)
+		}
+		if err != nil {
+			err = fmt.Errorf("socket activation: %w", err)
+		}
+
+		activationMutex.Lock()
+		defer activationMutex.Unlock()
+		activated, activationErr = inherited, err
+	})
+}
+
+// takeActivated hands out the inherited public or admin listener once.
+// It returns nil when no such socket was passed in.
+func takeActivated(admin bool) (net.Listener, error) {
+	loadActivation()
+
+	activationMutex.Lock()
+	defer activationMutex.Unlock()
+
+	if activationErr != nil {
+		return nil, activationErr
+	}
+	listener := &activated.public
+	if admin {
+		listener = &activated.admin
+	}
+	taken := *listener
+	*listener = nil
+	if taken != nil {
+		logging.Info("Using inherited listener %s", taken.Addr())
+	}
+	return taken, nil
+}
+
+// hasActivatedAdmin reports whether an admin socket was passed in
+func hasActivatedAdmin() bool {
+	loadActivation()
+
+	activationMutex.Lock()
+	defer activationMutex.Unlock()
+
+	return activated.admin != nil
+}
+
+// activationFiles returns the sockets passed by systemd or by Upgrade and
+// their names. It returns nothing when the variables are unset or meant for
+// another process.
+func activationFiles(getenv func(string) string, pid int) ([]*os.File, []string, error) {
+	if getenv(listenFDsEnv) == "" {
+		return nil, nil, nil
+	}
+	// Upgrade sets no LISTEN_PID, as it starts the process it passes sockets to
+	if getenv(listenPIDEnv) != "" || getenv(upgradeReadyFDEnv) == "" {
+		if listenPID, err := strconv.Atoi(getenv(listenPIDEnv)); err != nil || listenPID != pid {
+			return nil, nil, nil
+		}
+	}
+
+	count, err := strconv.Atoi(getenv(listenFDsEnv))
+	if err != nil || count < 0 {
+		return nil, nil, fmt.Errorf("invalid %s %q", listenFDsEnv, getenv(listenFDsEnv))
+	}
+	names := make([]string, count)
+	if value := getenv(listenFDNamesEnv); value != "" {
+		names = strings.Split(value, ":")
+		if len(names) != count {
+			return nil, nil, fmt.Errorf("%s lists %d names for %d sockets", listenFDNamesEnv, len(names), count)
+		}
+	}
+
+	files := make([]*os.File, count)
+	for i := range files {
+		fd := listenFDsStart + i
+		files[i] = os.NewFile(uintptr(fd), fmt.Sprintf("%s:%d", listenFDsEnv, fd))
+	}
+	return files, names, nil
+}
+
+// newInheritedListeners turns inherited socket files into listeners. The
+// files are closed; the listeners hold their own copies of the descriptors.
+func newInheritedListeners(files []*os.File, names []string) (inheritedListeners, error) {
+	var inherited inheritedListeners
+	var errs []error
+	for i, file := range files {
+		listener, err := net.FileListener(file)
+		file.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("socket %d (%q) is not a listening socket: %w", i, names[i], err))
+			continue
+		}
+
+		switch {
+		case names[i] == AdminSocketName && inherited.admin == nil:
+			inherited.admin = listener
+		case names[i] != AdminSocketName && inherited.public == nil:
+			inherited.public = listener
+		default:
+			listener.Close()
+			errs = append(errs, fmt.Errorf("unexpected extra socket %d (%q)", i, names[i]))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		for _, listener := range []net.Listener{inherited.public, inherited.admin} {
+			if listener != nil {
+				listener.Close()
+			}
+		}
+		return inheritedListeners{}, err
+	}
+	return inherited, nil
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// socketFile returns a duplicate of the listener's descriptor, as a
+// service manager would pass it
+func socketFile(t *testing.T, listener net.Listener) *os.File {
+	file, err := listener.(interface{ File() (*os.File, error) }).File()
+	require.NoError(t, err)
+	return file
+}
+
+// useActivated installs inherited listeners for the duration of a test
+func useActivated(t *testing.T, inherited inheritedListeners) {
+	loadActivation()
+	activationMutex.Lock()
+	activated, activationErr = inherited, nil
+	activationMutex.Unlock()
+	t.Cleanup(func() {
+		activationMutex.Lock()
+		activated, activationErr = inheritedListeners{}, nil
+		activationMutex.Unlock()
+	})
+}
+
+// serveText serves a fixed body on listener until the test ends
+func serveText(t *testing.T, listener net.Listener, body string) {
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, body)
+	})}
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(func() { srv.Close() })
+}
+
+// get returns the body of a GET request
+func get(t *testing.T, client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestActivationFiles(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	for name, tc := range map[string]struct {
+		env      map[string]string
+		expected int
+		err      string
+	}{
+		"unset":         {env: map[string]string{}},
+		"other process": {env: map[string]string{listenPIDEnv: "1", listenFDsEnv: "1"}},
+		"no sockets":    {env: map[string]string{listenPIDEnv: pid, listenFDsEnv: "0"}},
+		"upgrade":       {env: map[string]string{listenFDsEnv: "0", upgradeReadyFDEnv: "3"}},
+		"no pid":        {env: map[string]string{listenFDsEnv: "1"}},
+		"invalid count": {env: map[string]string{listenPIDEnv: pid, listenFDsEnv: "two"}, err: `invalid LISTEN_FDS "two"`},
+		"name mismatch": {env: map[string]string{listenPIDEnv: pid, listenFDsEnv: "2", listenFDNamesEnv: "http"}, err: "LISTEN_FDNAMES lists 1 names for 2 sockets"},
+	} {
+		files, names, err := activationFiles(func(key string) string { return tc.env[key] }, os.Getpid())
+		if tc.err != "" {
+			assert.ErrorContains(t, err, tc.err, name)
+			continue
+		}
+		require.NoError(t, err, name)
+		assert.Len(t, files, tc.expected, name)
+		assert.Len(t, names, tc.expected, name)
+	}
+}
+
+func TestNewInheritedListeners(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer tcp.Close()
+	path := filepath.Join(t.TempDir(), "admin.sock")
+	unix, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer unix.Close()
+
+	inherited, err := newInheritedListeners(
+		[]*os.File{socketFile(t, unix), socketFile(t, tcp)},
+		[]string{AdminSocketName, "http"},
+	)
+	require.NoError(t, err)
+	serveText(t, inherited.public, "public")
+	serveText(t, inherited.admin, "admin")
+
+	assert.Equal(t, "public", get(t, http.DefaultClient, "http://"+tcp.Addr().String()))
+	assert.Equal(t, "admin", get(t, unixClient(path), "http://admin/"))
+
+	// A second public socket is ambiguous
+	_, err = newInheritedListeners(
+		[]*os.File{socketFile(t, tcp), socketFile(t, tcp)},
+		[]string{"", ""},
+	)
+	assert.ErrorContains(t, err, `unexpected extra socket 1 ("")`)
+
+	// Regular files are not sockets
+	file, err := os.CreateTemp(t.TempDir(), "not-a-socket")
+	require.NoError(t, err)
+	_, err = newInheritedListeners([]*os.File{file}, []string{"http"})
+	assert.ErrorContains(t, err, `socket 0 ("http") is not a listening socket`)
+}
+
+func TestListen_PrefersActivatedSocket(t *testing.T) {
+	inheritedTCP, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer inheritedTCP.Close()
+	path := filepath.Join(t.TempDir(), "admin.sock")
+	inheritedUnix, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer inheritedUnix.Close()
+	useActivated(t, inheritedListeners{public: inheritedTCP, admin: inheritedUnix})
+
+	cfg := Config{Host: "127.0.0.1", Port: "0"}
+	assert.True(t, cfg.AdminEnabled())
+
+	listener, err := cfg.Listen()
+	require.NoError(t, err)
+	assert.Same(t, inheritedTCP, listener)
+	admin, err := cfg.ListenAdmin()
+	require.NoError(t, err)
+	assert.Same(t, inheritedUnix, admin)
+	assert.False(t, cfg.AdminEnabled())
+
+	// Inherited sockets are handed out once; later calls listen themselves
+	listener, err = cfg.Listen()
+	require.NoError(t, err)
+	defer listener.Close()
+	assert.NotEqual(t, inheritedTCP.Addr().String(), listener.Addr().String())
+}
@@ -0,0 +1,280 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/config"
+)
+
+// DefaultPort is used when neither server.port nor server_port is set
+const DefaultPort = "8080"
+
+// Config holds the listener and HTTP server settings
+type Config struct {
+	Host string
+	Port string
+	// Address is a host:port or unix:///path that replaces Host and Port
+	Address string
+	// SocketMode sets the permissions of unix sockets; zero keeps the umask default
+	SocketMode fs.FileMode
+	// SocketGroup is the group name or ID that owns unix sockets
+	SocketGroup       string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	MaxBodyBytes      int64
+	KeepAlives        bool
+	// TCPKeepAlive is the keep-alive probe period; zero uses the Go default
+	// and a negative value disables TCP keep-alives
+	TCPKeepAlive time.Duration
+	// WriteTimeouts overrides WriteTimeout for routes, keyed by "METHOD /path"
+	// or "/path". Zero removes the deadline.
+	WriteTimeouts   map[string]time.Duration
+	DrainDelay      time.Duration
+	ShutdownTimeout time.Duration
+	// UpgradeTimeout bounds the wait for the new process during a binary upgrade
+	UpgradeTimeout time.Duration
+	TLS            TLSConfig
+	HTTP2          HTTP2Config
+	// AdminAddress is the host:port or unix:///path of the admin listener
+	// serving admin-only routes; they are not served when it is empty
+	AdminAddress string
+	// AdminPublicProbes keeps the health routes on the public listener when
+	// admin-only routes have a listener
+	AdminPublicProbes bool
+}
+
+// LoadConfig reads the server section of the configuration and validates it
+func LoadConfig() (Config, error) {
+	var errs []error
+	duration := func(key string) time.Duration {
+		value := config.GetString(key)
+		if value == "" {
+			return 0
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid duration %q", key, value))
+		}
+		return d
+	}
+
+	cfg := Config{
+		Host:              config.GetString(config.ServerHostKey),
+		Port:              config.GetString(config.ServerPortKey),
+		ReadTimeout:       duration(config.ServerReadTimeoutKey),
+		ReadHeaderTimeout: duration(config.ServerReadHeaderTimeoutKey),
+		WriteTimeout:      duration(config.ServerWriteTimeoutKey),
+		IdleTimeout:       duration(config.ServerIdleTimeoutKey),
+		MaxHeaderBytes:    config.GetInt(config.ServerMaxHeaderBytesKey),
+		MaxBodyBytes:      int64(config.GetInt(config.ServerMaxBodyBytesKey)),
+		KeepAlives:        config.GetBool(config.ServerKeepAlivesKey),
+		TCPKeepAlive:      duration(config.ServerTCPKeepAliveKey),
+		DrainDelay:        duration(config.ServerDrainDelayKey),
+		ShutdownTimeout:   duration(config.ServerShutdownTimeoutKey),
+		UpgradeTimeout:    duration(config.ServerUpgradeTimeoutKey),
+	}
+	cfg.TLS = loadTLSConfig(duration)
+	cfg.HTTP2 = loadHTTP2Config()
+	cfg.AdminAddress = config.GetString(config.ServerAdminAddressKey)
+	cfg.AdminPublicProbes = config.GetBool(config.ServerAdminPublicProbesKey)
+	cfg.Address = config.GetString(config.ServerAddressKey)
+	cfg.SocketGroup = config.GetString(config.ServerUnixSocketGroupKey)
+	if value := config.GetString(config.ServerUnixSocketModeKey); value != "" {
+		mode, err := strconv.ParseUint(value, 8, 32)
+		if err != nil || mode > 0777 {
+			errs = append(errs, fmt.Errorf("%s: invalid mode %q: expected octal permissions such as 0660", config.ServerUnixSocketModeKey, value))
+		}
+		cfg.SocketMode = fs.FileMode(mode) & fs.ModePerm
+	}
+	if cfg.Port == "" {
+		cfg.Port = config.GetString(config.LegacyServerPortKey)
+	}
+	if cfg.Port == "" {
+		cfg.Port = DefaultPort
+	}
+
+	overrides := config.GetStringMapString(config.ServerWriteTimeoutsKey)
+	if len(overrides) > 0 {
+		cfg.WriteTimeouts = make(map[string]time.Duration, len(overrides))
+		for route, value := range overrides {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid duration %q for %q", config.ServerWriteTimeoutsKey, value, route))
+				continue
+			}
+			cfg.WriteTimeouts[route] = d
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate reports every invalid setting
+func (c Config) Validate() error {
+	var errs []error
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 0 || port > 65535 {
+		errs = append(errs, fmt.Errorf("%s: invalid port %q", config.ServerPortKey, c.Port))
+	}
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{config.ServerReadTimeoutKey, c.ReadTimeout},
+		{config.ServerReadHeaderTimeoutKey, c.ReadHeaderTimeout},
+		{config.ServerWriteTimeoutKey, c.WriteTimeout},
+		{config.ServerIdleTimeoutKey, c.IdleTimeout},
+		{config.ServerDrainDelayKey, c.DrainDelay},
+		{config.ServerShutdownTimeoutKey, c.ShutdownTimeout},
+		{config.ServerUpgradeTimeoutKey, c.UpgradeTimeout},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("%s: must not be negative", d.key))
+		}
+	}
+	if c.ReadTimeout > 0 && c.ReadHeaderTimeout > c.ReadTimeout {
+		errs = append(errs, fmt.Errorf("%s: must not exceed %s", config.ServerReadHeaderTimeoutKey, config.ServerReadTimeoutKey))
+	}
+	if c.MaxHeaderBytes <= 0 {
+		errs = append(errs, fmt.Errorf("%s: must be positive", config.ServerMaxHeaderBytesKey))
+	}
+	if c.MaxBodyBytes < 0 {
+		errs = append(errs, fmt.Errorf("%s: must not be negative", config.ServerMaxBodyBytesKey))
+	}
+	if c.Address != "" {
+		if err := validateAddress(config.ServerAddressKey, c.Address); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, c.TLS.validate()...)
+	errs = append(errs, c.HTTP2.validate()...)
+	if c.HTTP2.H2C && c.TLS.Enabled() {
+		errs = append(errs, fmt.Errorf("%s: cannot be combined with TLS, which negotiates HTTP/2 itself", config.ServerHTTP2H2CKey))
+	}
+	if c.AdminAddress != "" {
+		if err := validateAddress(config.ServerAdminAddressKey, c.AdminAddress); err != nil {
+			errs = append(errs, err)
+		} else if c.AdminAddress == c.Addr() {
+			errs = append(errs, fmt.Errorf("%s: must differ from the public address", config.ServerAdminAddressKey))
+		}
+	}
+	for _, route := range sortedKeys(c.WriteTimeouts) {
+		if c.WriteTimeouts[route] < 0 {
+			errs = append(errs, fmt.Errorf("%s: %q must not be negative", config.ServerWriteTimeoutsKey, route))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Addr returns the address the server listens on: Address when set,
+// otherwise Host and Port
+func (c Config) Addr() string {
+	if c.Address != "" {
+		return c.Address
+	}
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// NewHTTPServer creates an http.Server using the configured timeouts, limits
+// and HTTP/2 settings
+func (c Config) NewHTTPServer(handler http.Handler) *http.Server {
+	srv := &http.Server{
+		Addr:              c.Addr(),
+		Handler:           LimitBody(c.MaxBodyBytes)(handler),
+		ReadTimeout:       c.ReadTimeout,
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+		MaxHeaderBytes:    c.MaxHeaderBytes,
+	}
+	srv.SetKeepAlivesEnabled(c.KeepAlives)
+	c.HTTP2.configure(srv)
+	return srv
+}
+
+// Listen returns the public listener: the socket inherited through socket
+// activation when there is one, otherwise a new listener on Addr()
+func (c Config) Listen() (net.Listener, error) {
+	if listener, err := takeActivated(false); listener != nil || err != nil {
+		return listener, err
+	}
+	return c.listen(c.Addr())
+}
+
+// ListenAdmin returns the admin listener: the inherited socket named
+// AdminSocketName when there is one, otherwise a new listener on AdminAddress
+func (c Config) ListenAdmin() (net.Listener, error) {
+	if listener, err := takeActivated(true); listener != nil || err != nil {
+		return listener, err
+	}
+	return c.listen(c.AdminAddress)
+}
+
+// AdminEnabled reports whether admin-only routes have a listener, either
+// AdminAddress or an inherited admin socket
+func (c Config) AdminEnabled() bool {
+	return c.AdminAddress != "" || hasActivatedAdmin()
+}
+
+// ApplyWriteTimeouts copies the WriteTimeouts overrides onto the registered
+// routes. It fails if an override matches no route. Call it before the
+// handler registry is built.
+func (c Config) ApplyWriteTimeouts() error {
+	if len(c.WriteTimeouts) == 0 {
+		return nil
+	}
+
+	routes := types.GetRegisteredRoutes()
+	var errs []error
+	for _, key := range sortedKeys(c.WriteTimeouts) {
+		method, path, hasMethod := strings.Cut(strings.TrimSpace(key), " ")
+		if !hasMethod {
+			method, path = "", method
+		}
+		timeout := c.WriteTimeouts[key]
+		if timeout == 0 {
+			timeout = types.NoWriteTimeout
+		}
+
+		matched := false
+		for i, route := range routes {
+			if strings.EqualFold(route.Path, strings.TrimSpace(path)) && (method == "" || strings.EqualFold(route.Method, method)) {
+				routes[i].WriteTimeout = timeout
+				matched = true
+			}
+		}
+		if !matched {
+			errs = append(errs, fmt.Errorf("%s: %q matches no registered route", config.ServerWriteTimeoutsKey, key))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	types.UpdateRouteRegistry(routes)
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]time.Duration) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
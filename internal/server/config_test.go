@@ -0,0 +1,192 @@
+package server
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"{{MODULE_NAME}}/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useConfig loads content as the config file for the duration of a test
+func useConfig(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	config.ResetForTest()
+	config.SetConfigPath(path)
+	t.Cleanup(config.ResetForTest)
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	useConfig(t, `{}`)
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Port:              DefaultPort,
+		SocketMode:        0660,
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		MaxBodyBytes:      10 << 20,
+		KeepAlives:        true,
+		TCPKeepAlive:      15 * time.Second,
+		DrainDelay:        5 * time.Second,
+		ShutdownTimeout:   30 * time.Second,
+		UpgradeTimeout:    30 * time.Second,
+		TLS: TLSConfig{
+			MinVersion:     "1.2",
+			CipherPolicy:   CipherPolicyDefault,
+			ClientAuth:     ClientAuthNone,
+			ReloadInterval: 30 * time.Second,
+		},
+	}, cfg)
+	assert.Equal(t, ":8080", cfg.Addr())
+}
+
+func TestLoadConfig_Overrides(t *testing.T) {
+	useConfig(t, `{
+		"server": {
+			"host": "127.0.0.1",
+			"port": "9090",
+			"read_header_timeout": "2s",
+			"keep_alives": false,
+			"max_body_bytes": 1024,
+			"write_timeouts": {"GET /api/docs/events": "0s", "/exports": "5m"}
+		}
+	}`)
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9090", cfg.Addr())
+	assert.Equal(t, 2*time.Second, cfg.ReadHeaderTimeout)
+	assert.False(t, cfg.KeepAlives)
+	assert.Equal(t, int64(1024), cfg.MaxBodyBytes)
+	assert.Equal(t, map[string]time.Duration{"get /api/docs/events": 0, "/exports": 5 * time.Minute}, cfg.WriteTimeouts)
+}
+
+func TestLoadConfig_LegacyPort(t *testing.T) {
+	useConfig(t, `{"server_port": "7070"}`)
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "7070", cfg.Port)
+}
+
+func TestLoadConfig_Address(t *testing.T) {
+	useConfig(t, `{"server": {"port": "9090", "address": "unix:///run/app.sock", "unix_socket_mode": "0600", "unix_socket_group": "www-data"}}`)
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "unix:///run/app.sock", cfg.Addr())
+	assert.Equal(t, fs.FileMode(0600), cfg.SocketMode)
+	assert.Equal(t, "www-data", cfg.SocketGroup)
+
+	useConfig(t, `{"server": {"address": "8080", "unix_socket_mode": "rw"}}`)
+	_, err = LoadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `server.unix_socket_mode: invalid mode "rw"`)
+
+	cfg.Address = "8080"
+	assert.ErrorContains(t, cfg.Validate(), `server.address: invalid address "8080"`)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	useConfig(t, `{
+		"server": {
+			"port": "http",
+			"read_timeout": "5s",
+			"read_header_timeout": "10s",
+			"idle_timeout": "forever",
+			"max_header_bytes": 0,
+			"write_timeouts": {"/exports": "-1s"}
+		}
+	}`)
+
+	_, err := LoadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `server.idle_timeout: invalid duration "forever"`)
+
+	cfg := Config{Port: "http", TLS: TLSConfig{MinVersion: "1.2", CipherPolicy: CipherPolicyDefault, ClientAuth: ClientAuthNone}, ReadTimeout: 5 * time.Second, ReadHeaderTimeout: 10 * time.Second, WriteTimeouts: map[string]time.Duration{"/exports": -time.Second}}
+	err = cfg.Validate()
+	require.Error(t, err)
+	for _, msg := range []string{
+		`server.port: invalid port "http"`,
+		"server.read_header_timeout: must not exceed server.read_timeout",
+		"server.max_header_bytes: must be positive",
+		`server.write_timeouts: "/exports" must not be negative`,
+	} {
+		assert.Contains(t, err.Error(), msg)
+	}
+}
+
+func TestApplyWriteTimeouts(t *testing.T) {
+	types.ClearRegistry()
+	defer types.ClearRegistry()
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/exports"})
+	types.RegisterRoute(types.RouteInfo{Method: "POST", Path: "/exports"})
+	types.RegisterRoute(types.RouteInfo{Method: "GET", Path: "/events"})
+
+	cfg := Config{WriteTimeouts: map[string]time.Duration{"post /exports": 5 * time.Minute, "/events": 0}}
+	require.NoError(t, cfg.ApplyWriteTimeouts())
+
+	routes := types.GetRegisteredRoutes()
+	assert.Equal(t, time.Duration(0), routes[0].WriteTimeout)
+	assert.Equal(t, 5*time.Minute, routes[1].WriteTimeout)
+	assert.Equal(t, types.NoWriteTimeout, routes[2].WriteTimeout)
+
+	cfg = Config{WriteTimeouts: map[string]time.Duration{"/missing": time.Second}}
+	assert.ErrorContains(t, cfg.ApplyWriteTimeouts(), `"/missing" matches no registered route`)
+}
+
+func TestLimitBody(t *testing.T) {
+	handler := LimitBody(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			types.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name   string
+		body   io.Reader
+		status int
+	}{
+		{"within limit", strings.NewReader("12345678"), http.StatusNoContent},
+		{"declared length too large", strings.NewReader("123456789"), http.StatusRequestEntityTooLarge},
+		{"streamed body too large", io.MultiReader(strings.NewReader("12345"), strings.NewReader("6789")), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", tt.body)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	cfg := Config{Host: "127.0.0.1", Port: "0", ReadHeaderTimeout: time.Second, MaxHeaderBytes: 4096, KeepAlives: true}
+	srv := cfg.NewHTTPServer(http.NotFoundHandler())
+	assert.Equal(t, "127.0.0.1:0", srv.Addr)
+	assert.Equal(t, time.Second, srv.ReadHeaderTimeout)
+	assert.Equal(t, 4096, srv.MaxHeaderBytes)
+
+	listener, err := cfg.Listen()
+	require.NoError(t, err)
+	defer listener.Close()
+	assert.Contains(t, listener.Addr().String(), "127.0.0.1:")
+}
@@ -0,0 +1,352 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"{{MODULE_NAME}}/internal/config"
+)
+
+// Frame size limits from RFC 9113
+const (
+	minFrameSize = 1 << 14
+	maxFrameSize = 1<<24 - 1
+)
+
+// HTTP2Config holds the HTTP/2 settings. Zero values use Go's defaults.
+type HTTP2Config struct {
+	// H2C accepts HTTP/2 without TLS, by prior knowledge or by upgrading an
+	// HTTP/1.1 request, alongside HTTP/1.1
+	H2C                           bool
+	MaxConcurrentStreams          int
+	MaxReadFrameSize              int
+	MaxReceiveBufferPerConnection int
+	MaxReceiveBufferPerStream     int
+}
+
+// loadHTTP2Config reads the server.http2 section
+func loadHTTP2Config() HTTP2Config {
+	return HTTP2Config{
+		H2C:                           config.GetBool(config.ServerHTTP2H2CKey),
+		MaxConcurrentStreams:          config.GetInt(config.ServerHTTP2MaxConcurrentStreamsKey),
+		MaxReadFrameSize:              config.GetInt(config.ServerHTTP2MaxReadFrameSizeKey),
+		MaxReceiveBufferPerConnection: config.GetInt(config.ServerHTTP2MaxReceiveBufferPerConnectionKey),
+		MaxReceiveBufferPerStream:     config.GetInt(config.ServerHTTP2MaxReceiveBufferPerStreamKey),
+	}
+}
+
+// validate reports invalid HTTP/2 settings
+func (c HTTP2Config) validate() []error {
+	var errs []error
+	if c.MaxConcurrentStreams < 0 {
+		errs = append(errs, fmt.Errorf("%s: must not be negative", config.ServerHTTP2MaxConcurrentStreamsKey))
+	}
+	if c.MaxReadFrameSize != 0 && (c.MaxReadFrameSize < minFrameSize || c.MaxReadFrameSize > maxFrameSize) {
+		errs = append(errs, fmt.Errorf("%s: must be between %d and %d", config.ServerHTTP2MaxReadFrameSizeKey, minFrameSize, maxFrameSize))
+	}
+	if c.MaxReceiveBufferPerConnection != 0 && c.MaxReceiveBufferPerConnection < 1<<16 {
+		errs = append(errs, fmt.Errorf("%s: must be at least %d", config.ServerHTTP2MaxReceiveBufferPerConnectionKey, 1<<16))
+	}
+	if c.MaxReceiveBufferPerStream < 0 {
+		errs = append(errs, fmt.Errorf("%s: must not be negative", config.ServerHTTP2MaxReceiveBufferPerStreamKey))
+	}
+	return errs
+}
+
+// configure applies the settings to srv
+func (c HTTP2Config) configure(srv *http.Server) {
+	srv.HTTP2 = &http.HTTP2Config{
+		MaxConcurrentStreams:          c.MaxConcurrentStreams,
+		MaxReadFrameSize:              c.MaxReadFrameSize,
+		MaxReceiveBufferPerConnection: c.MaxReceiveBufferPerConnection,
+		MaxReceiveBufferPerStream:     c.MaxReceiveBufferPerStream,
+	}
+	if c.H2C {
+		var protocols http.Protocols
+		protocols.SetHTTP1(true)
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		srv.Protocols = &protocols
+	}
+}
+
+// acceptsH2C reports whether srv serves HTTP/2 over cleartext
+func acceptsH2C(srv *http.Server) bool {
+	return srv.TLSConfig == nil && srv.Protocols != nil && srv.Protocols.UnencryptedHTTP2()
+}
+
+// h2cPrefaceTimeout bounds the wait for the client preface after a 101 response
+const h2cPrefaceTimeout = 10 * time.Second
+
+// clientPreface starts every HTTP/2 connection
+const clientPreface = "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"
+
+// HTTP/2 frame types and flags used to replay an upgraded request
+const (
+	frameHeaders      = 0x1
+	frameSettings     = 0x4
+	frameContinuation = 0x9
+	flagEndStream     = 0x1
+	flagAck           = 0x1
+	flagEndHeaders    = 0x4
+)
+
+// h2cListener accepts connections from the wrapped listener as well as
+// connections upgraded to h2c, which the server then serves as HTTP/2
+type h2cListener struct {
+	net.Listener
+	accepted  chan acceptResult
+	upgraded  chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// acceptResult is the outcome of one Accept on the wrapped listener
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+// newH2CListener wraps listener and starts accepting from it
+func newH2CListener(listener net.Listener) *h2cListener {
+	l := &h2cListener{
+		Listener: listener,
+		accepted: make(chan acceptResult),
+		upgraded: make(chan net.Conn),
+		closed:   make(chan struct{}),
+	}
+	go l.acceptLoop()
+	return l
+}
+
+// acceptLoop hands connections from the wrapped listener to Accept
+func (l *h2cListener) acceptLoop() {
+	for {
+		conn, err := l.Listener.Accept()
+		select {
+		case l.accepted <- acceptResult{conn, err}:
+		case <-l.closed:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+	}
+}
+
+// Accept returns the next new or upgraded connection
+func (l *h2cListener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.accepted:
+		return result.conn, result.err
+	case conn := <-l.upgraded:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close stops accepting connections
+func (l *h2cListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return l.Listener.Close()
+}
+
+// h2cConn replays the bytes read during the upgrade before reading from the connection
+type h2cConn struct {
+	net.Conn
+	r io.Reader
+}
+
+// Read reads the replayed bytes, then the connection
+func (c *h2cConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// h2cUpgrade switches HTTP/1.1 requests carrying "Upgrade: h2c" to HTTP/2.
+// The request is replayed as stream 1 of a new connection handed to the
+// server through listener. Requests with a body stay on HTTP/1.1, which
+// RFC 9113 allows.
+func h2cUpgrade(listener *h2cListener, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isH2CUpgrade(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		conn, rw, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		upgraded, err := switchToH2C(conn, rw, r)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		select {
+		case listener.upgraded <- upgraded:
+		case <-listener.closed:
+			conn.Close()
+		}
+	})
+}
+
+// isH2CUpgrade reports whether r asks to upgrade to h2c and has no body
+func isH2CUpgrade(r *http.Request) bool {
+	return r.ProtoAtLeast(1, 1) && r.TLS == nil &&
+		hasToken(r.Header, "Upgrade", "h2c") &&
+		hasToken(r.Header, "Connection", "upgrade") &&
+		hasToken(r.Header, "Connection", "http2-settings") &&
+		len(r.Header.Values("HTTP2-Settings")) == 1 &&
+		r.ContentLength == 0 && len(r.TransferEncoding) == 0
+}
+
+// hasToken reports whether a comma-separated header lists token
+func hasToken(h http.Header, key, token string) bool {
+	for _, value := range h.Values(key) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// switchToH2C answers 101, reads the client preface and returns a connection
+// that starts with the preface, the client's settings and r as stream 1
+func switchToH2C(conn net.Conn, rw *bufio.ReadWriter, r *http.Request) (net.Conn, error) {
+	if _, err := rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: h2c\r\n\r\n"); err != nil {
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		return nil, err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(h2cPrefaceTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+	prefix := make([]byte, len(clientPreface)+9)
+	if _, err := io.ReadFull(rw, prefix); err != nil {
+		return nil, err
+	}
+	if string(prefix[:len(clientPreface)]) != clientPreface {
+		return nil, errors.New("invalid client preface")
+	}
+	header := prefix[len(clientPreface):]
+	length := int(header[0])<<16 | int(header[1])<<8 | int(header[2])
+	if header[3] != frameSettings || header[4]&flagAck != 0 || binary.BigEndian.Uint32(header[5:]) != 0 || length > minFrameSize {
+		return nil, errors.New("expected a SETTINGS frame after the client preface")
+	}
+	settings := make([]byte, length)
+	if _, err := io.ReadFull(rw, settings); err != nil {
+		return nil, err
+	}
+
+	replay := bytes.NewBuffer(prefix)
+	replay.Write(settings)
+	writeHeaderFrames(replay, 1, encodeRequestHeaders(r))
+	return &h2cConn{Conn: conn, r: io.MultiReader(replay, rw)}, nil
+}
+
+// connectionHeaders are HTTP/1.1 headers that must not appear in HTTP/2
+var connectionHeaders = map[string]bool{
+	"Connection":        true,
+	"Http2-Settings":    true,
+	"Keep-Alive":        true,
+	"Proxy-Connection":  true,
+	"Transfer-Encoding": true,
+	"Upgrade":           true,
+}
+
+// encodeRequestHeaders HPACK-encodes the request line and headers of r as
+// literals, which leaves the server's dynamic table untouched
+func encodeRequestHeaders(r *http.Request) []byte {
+	var block []byte
+	for _, field := range [][2]string{
+		{":method", r.Method},
+		{":scheme", "http"},
+		{":authority", r.Host},
+		{":path", r.URL.RequestURI()},
+	} {
+		block = appendLiteralField(block, field[0], field[1])
+	}
+	for key, values := range r.Header {
+		if connectionHeaders[key] || key == "Te" {
+			continue
+		}
+		for _, value := range values {
+			block = appendLiteralField(block, strings.ToLower(key), value)
+		}
+	}
+	if hasToken(r.Header, "Te", "trailers") {
+		block = appendLiteralField(block, "te", "trailers")
+	}
+	return block
+}
+
+// appendLiteralField appends a literal header field without indexing
+func appendLiteralField(block []byte, name, value string) []byte {
+	block = append(block, 0)
+	block = appendHPACKString(block, name)
+	return appendHPACKString(block, value)
+}
+
+// appendHPACKString appends a string literal without Huffman coding
+func appendHPACKString(block []byte, s string) []byte {
+	block = appendHPACKInt(block, 7, uint64(len(s)))
+	return append(block, s...)
+}
+
+// appendHPACKInt appends an integer with an n-bit prefix (RFC 7541 5.1)
+func appendHPACKInt(block []byte, n uint, v uint64) []byte {
+	limit := uint64(1)<<n - 1
+	if v < limit {
+		return append(block, byte(v))
+	}
+	block = append(block, byte(limit))
+	for v -= limit; v >= 128; v >>= 7 {
+		block = append(block, byte(v&0x7f|0x80))
+	}
+	return append(block, byte(v))
+}
+
+// writeHeaderFrames writes a header block for a request without a body as a
+// HEADERS frame followed by CONTINUATION frames where needed
+func writeHeaderFrames(w *bytes.Buffer, streamID uint32, block []byte) {
+	frameType, flags := byte(frameHeaders), byte(flagEndStream)
+	for {
+		chunk := block
+		if len(chunk) > minFrameSize {
+			chunk = chunk[:minFrameSize]
+		}
+		block = block[len(chunk):]
+		if len(block) == 0 {
+			flags |= flagEndHeaders
+		}
+
+		var header [9]byte
+		header[0], header[1], header[2] = byte(len(chunk)>>16), byte(len(chunk)>>8), byte(len(chunk))
+		header[3], header[4] = frameType, flags
+		binary.BigEndian.PutUint32(header[5:], streamID)
+		w.Write(header[:])
+		w.Write(chunk)
+
+		if len(block) == 0 {
+			return
+		}
+		frameType, flags = frameContinuation, 0
+	}
+}
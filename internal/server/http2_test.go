@@ -0,0 +1,226 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// protoHandler echoes the protocol and request line it was served with
+var protoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_, _ = io.WriteString(w, strings.Join([]string{r.Proto, r.Host, r.URL.RequestURI(), r.Header.Get("X-Test")}, " "))
+})
+
+// h2cConfig returns a valid cleartext config, with h2c when enabled
+func h2cConfig(enabled bool) Config {
+	cfg := testTLSConfig("", "")
+	cfg.HTTP2.H2C = enabled
+	return cfg
+}
+
+// h2cClient speaks HTTP/2 with prior knowledge only
+func h2cClient() *http.Client {
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	return &http.Client{Transport: &http.Transport{Protocols: &protocols}}
+}
+
+// readFrame reads one HTTP/2 frame
+func readFrame(t *testing.T, r io.Reader) (frameType, flags byte, streamID uint32, payload []byte) {
+	var header [9]byte
+	_, err := io.ReadFull(r, header[:])
+	require.NoError(t, err)
+	payload = make([]byte, int(header[0])<<16|int(header[1])<<8|int(header[2]))
+	_, err = io.ReadFull(r, payload)
+	require.NoError(t, err)
+	return header[3], header[4], binary.BigEndian.Uint32(header[5:]) & 0x7fffffff, payload
+}
+
+// readResponseBody reads frames until the DATA of stream ends and returns
+// its HEADERS and DATA payloads
+func readResponseBody(t *testing.T, r io.Reader, stream uint32) (headers []byte, body string) {
+	for {
+		frameType, flags, streamID, payload := readFrame(t, r)
+		if streamID != stream {
+			continue
+		}
+		switch frameType {
+		case frameHeaders:
+			headers = payload
+		case 0x0:
+			body += string(payload)
+		}
+		if flags&flagEndStream != 0 {
+			return headers, body
+		}
+	}
+}
+
+// startH2 writes the client preface and an empty SETTINGS frame
+func startH2(t *testing.T, w io.Writer) {
+	_, err := io.WriteString(w, clientPreface+"\x00\x00\x00\x04\x00\x00\x00\x00\x00")
+	require.NoError(t, err)
+}
+
+// serverSettings returns the values of the server's first SETTINGS frame
+func serverSettings(t *testing.T, r io.Reader) map[uint16]uint32 {
+	frameType, _, _, payload := readFrame(t, r)
+	require.Equal(t, byte(frameSettings), frameType)
+	settings := make(map[uint16]uint32)
+	for i := 0; i+6 <= len(payload); i += 6 {
+		settings[binary.BigEndian.Uint16(payload[i:])] = binary.BigEndian.Uint32(payload[i+2:])
+	}
+	return settings
+}
+
+func TestH2C_PriorKnowledge(t *testing.T) {
+	addr := serveTLS(t, h2cConfig(true), protoHandler)
+
+	assert.Equal(t, "HTTP/2.0 "+addr+" /prior ", get(t, h2cClient(), "http://"+addr+"/prior"))
+	assert.Equal(t, "HTTP/1.1 "+addr+" /plain ", get(t, http.DefaultClient, "http://"+addr+"/plain"))
+}
+
+func TestH2C_Upgrade(t *testing.T) {
+	addr := serveTLS(t, h2cConfig(true), protoHandler)
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, err = io.WriteString(conn, "GET /upgrade?step=1 HTTP/1.1\r\nHost: example.test\r\nX-Test: upgraded\r\n"+
+		"Connection: Upgrade, HTTP2-Settings\r\nUpgrade: h2c\r\nHTTP2-Settings: AAMAAABkAAQAoAAAAAIAAAAA\r\n\r\n")
+	require.NoError(t, err)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "h2c", resp.Header.Get("Upgrade"))
+
+	// The upgrade request is answered on stream 1
+	startH2(t, conn)
+	headers, body := readResponseBody(t, br, 1)
+	assert.Equal(t, byte(0x88), headers[0], "indexed :status 200")
+	assert.Equal(t, "HTTP/2.0 example.test /upgrade?step=1 upgraded", body)
+
+	// The connection carries on as HTTP/2
+	req, err := http.NewRequest(http.MethodGet, "http://example.test/next", nil)
+	require.NoError(t, err)
+	var frames bytes.Buffer
+	writeHeaderFrames(&frames, 3, encodeRequestHeaders(req))
+	_, err = conn.Write(frames.Bytes())
+	require.NoError(t, err)
+	_, body = readResponseBody(t, br, 3)
+	assert.Equal(t, "HTTP/2.0 example.test /next ", body)
+}
+
+func TestH2C_UpgradeWithBodyStaysOnHTTP1(t *testing.T) {
+	addr := serveTLS(t, h2cConfig(true), protoHandler)
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = io.WriteString(conn, "POST /body HTTP/1.1\r\nHost: example.test\r\nContent-Length: 4\r\n"+
+		"Connection: Upgrade, HTTP2-Settings\r\nUpgrade: h2c\r\nHTTP2-Settings: AAMAAABk\r\n\r\nping")
+	require.NoError(t, err)
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HTTP/1.1 example.test /body ", string(body))
+}
+
+func TestH2C_Disabled(t *testing.T) {
+	addr := serveTLS(t, h2cConfig(false), protoHandler)
+
+	_, err := h2cClient().Get("http://" + addr + "/prior")
+	assert.Error(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+addr+"/upgrade", nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade, HTTP2-Settings")
+	req.Header.Set("Upgrade", "h2c")
+	req.Header.Set("HTTP2-Settings", "AAMAAABk")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HTTP/1.1 "+addr+" /upgrade ", string(body))
+}
+
+func TestHTTP2_Settings(t *testing.T) {
+	settings := HTTP2Config{MaxConcurrentStreams: 7, MaxReadFrameSize: 1 << 15}
+
+	// h2c by prior knowledge
+	cfg := h2cConfig(true)
+	cfg.HTTP2.MaxConcurrentStreams, cfg.HTTP2.MaxReadFrameSize = settings.MaxConcurrentStreams, settings.MaxReadFrameSize
+	conn, err := net.Dial("tcp", serveTLS(t, cfg, protoHandler))
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	startH2(t, conn)
+	values := serverSettings(t, conn)
+	assert.Equal(t, uint32(7), values[0x3], "SETTINGS_MAX_CONCURRENT_STREAMS")
+	assert.Equal(t, uint32(1<<15), values[0x5], "SETTINGS_MAX_FRAME_SIZE")
+
+	// HTTP/2 negotiated through ALPN
+	ca := newTestCA(t, "Test CA")
+	certPEM, keyPEM := ca.issue(t, "localhost", 2, x509.ExtKeyUsageServerAuth)
+	dir := t.TempDir()
+	cfg = testTLSConfig(writeFile(t, dir, "cert.pem", certPEM), writeFile(t, dir, "key.pem", keyPEM))
+	cfg.HTTP2 = settings
+	addr := serveTLS(t, cfg, protoHandler)
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca.pem)
+	tlsConn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: pool, NextProtos: []string{"h2", "http/1.1"}})
+	require.NoError(t, err)
+	defer tlsConn.Close()
+	tlsConn.SetDeadline(time.Now().Add(5 * time.Second))
+	assert.Equal(t, "h2", tlsConn.ConnectionState().NegotiatedProtocol)
+	startH2(t, tlsConn)
+	values = serverSettings(t, tlsConn)
+	assert.Equal(t, uint32(7), values[0x3], "SETTINGS_MAX_CONCURRENT_STREAMS")
+	assert.Equal(t, uint32(1<<15), values[0x5], "SETTINGS_MAX_FRAME_SIZE")
+
+	// Clients without HTTP/2 fall back to HTTP/1.1
+	http1, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: pool, NextProtos: []string{"http/1.1"}})
+	require.NoError(t, err)
+	defer http1.Close()
+	assert.Equal(t, "http/1.1", http1.ConnectionState().NegotiatedProtocol)
+}
+
+func TestValidate_HTTP2(t *testing.T) {
+	cfg := testTLSConfig("cert.pem", "key.pem")
+	cfg.HTTP2 = HTTP2Config{
+		H2C:                           true,
+		MaxConcurrentStreams:          -1,
+		MaxReadFrameSize:              1024,
+		MaxReceiveBufferPerConnection: 1024,
+		MaxReceiveBufferPerStream:     -1,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	for _, msg := range []string{
+		"server.http2.h2c: cannot be combined with TLS",
+		"server.http2.max_concurrent_streams: must not be negative",
+		"server.http2.max_read_frame_size: must be between 16384 and 16777215",
+		"server.http2.max_receive_buffer_per_connection: must be at least 65536",
+		"server.http2.max_receive_buffer_per_stream: must not be negative",
+	} {
+		assert.Contains(t, err.Error(), msg)
+	}
+
+	assert.NoError(t, h2cConfig(true).Validate())
+}
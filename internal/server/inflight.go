@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// Tracker counts the requests currently being served
+type Tracker struct {
+	active atomic.Int64
+}
+
+// NewTracker creates an in-flight request tracker
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Middleware counts requests for the duration of next
+func (t *Tracker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.active.Add(1)
+		defer t.active.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Count returns the number of requests in flight
+func (t *Tracker) Count() int64 {
+	return t.active.Load()
+}
+
+// count returns the number of requests in flight, or zero for a nil tracker
+func (t *Tracker) count() int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Count()
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http"
+
+	"{{MODULE_NAME}}/internal/api/types"
+)
+
+// LimitBody returns middleware rejecting request bodies larger than maxBytes
+// with a 413. Bodies without a declared length fail when read past the limit.
+// Zero disables the limit.
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if maxBytes <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				types.WriteError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
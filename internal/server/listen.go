@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"{{MODULE_NAME}}/internal/config"
+)
+
+// unixScheme prefixes listen addresses that name a unix domain socket
+const unixScheme = "unix://"
+
+// unixSocketPath returns the socket path of a unix:// address
+func unixSocketPath(address string) (string, bool) {
+	return strings.CutPrefix(address, unixScheme)
+}
+
+// validateAddress checks a host:port or unix:// listen address
+func validateAddress(key, address string) error {
+	if path, ok := unixSocketPath(address); ok {
+		if path == "" {
+			return fmt.Errorf("%s: unix socket path is empty", key)
+		}
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("%s: invalid address %q: expected host:port or unix:///path", key, address)
+	}
+	return nil
+}
+
+// listen opens a TCP listener, or a unix socket for unix:// addresses
+func (c Config) listen(address string) (net.Listener, error) {
+	lc := net.ListenConfig{KeepAlive: c.TCPKeepAlive}
+	path, ok := unixSocketPath(address)
+	if !ok {
+		return lc.Listen(context.Background(), "tcp", address)
+	}
+
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	listener, err := lc.Listen(context.Background(), "unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.setSocketPermissions(path); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// setSocketPermissions applies SocketMode and SocketGroup to a socket file
+func (c Config) setSocketPermissions(path string) error {
+	if c.SocketGroup != "" {
+		gid, err := lookupGroup(c.SocketGroup)
+		if err != nil {
+			return err
+		}
+		if err := os.Chown(path, -1, gid); err != nil {
+			return fmt.Errorf("failed to set group of %s: %w", path, err)
+		}
+	}
+	if c.SocketMode != 0 {
+		if err := os.Chmod(path, c.SocketMode); err != nil {
+			return fmt.Errorf("failed to set mode of %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// lookupGroup resolves a group name or numeric group ID
+func lookupGroup(name string) (int, error) {
+	if gid, err := strconv.Atoi(name); err == nil {
+		return gid, nil
+	}
+	group, err := user.LookupGroup(name)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", config.ServerUnixSocketGroupKey, err)
+	}
+	return strconv.Atoi(group.Gid)
+}
+
+// removeStaleSocket deletes a socket file left behind by a process that
+// exited without closing it. A socket something still listens on is kept.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is in use by another process", path)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("failed to check socket %s: %w", path, err)
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unixClient returns an HTTP client that dials the socket at path
+func unixClient(path string) *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}
+}
+
+func TestListenAdmin_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "admin.sock")
+	cfg := Config{Port: "0", AdminAddress: "unix://" + path}
+
+	listener, err := cfg.ListenAdmin()
+	require.NoError(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "admin")
+	})}
+	go func() { _ = srv.Serve(listener) }()
+
+	resp, err := unixClient(path).Get("http://admin/")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "admin", string(body))
+
+	// A second listener must not steal a live socket
+	_, err = cfg.ListenAdmin()
+	assert.ErrorContains(t, err, "in use by another process")
+
+	require.NoError(t, srv.Close())
+}
+
+func TestListen_RemovesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stale.sock")
+
+	// Leave a socket file behind without anything listening on it
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	listener, err := Config{}.listen("unix://" + path)
+	require.NoError(t, err)
+	listener.Close()
+
+	regular := filepath.Join(t.TempDir(), "regular")
+	require.NoError(t, os.WriteFile(regular, nil, 0600))
+	_, err = Config{}.listen("unix://" + regular)
+	assert.ErrorContains(t, err, "is not a socket")
+}
+
+func TestListen_UnixSocketPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+	cfg := Config{Address: "unix://" + path, SocketMode: 0600, SocketGroup: strconv.Itoa(os.Getgid())}
+
+	listener, err := cfg.Listen()
+	require.NoError(t, err)
+	defer listener.Close()
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0600), info.Mode().Perm())
+	assert.Equal(t, uint32(os.Getgid()), info.Sys().(*syscall.Stat_t).Gid)
+
+	cfg.Address = "unix://" + filepath.Join(t.TempDir(), "other.sock")
+	cfg.SocketGroup = "no-such-group-for-tests"
+	_, err = cfg.Listen()
+	assert.ErrorContains(t, err, "server.unix_socket_group")
+}
+
+func TestValidate_AdminAddress(t *testing.T) {
+	valid := Config{Port: "8080", MaxHeaderBytes: 1, TLS: TLSConfig{MinVersion: "1.2", CipherPolicy: CipherPolicyDefault, ClientAuth: ClientAuthNone}}
+
+	for address, expected := range map[string]string{
+		"127.0.0.1:9090":         "",
+		"unix:///run/admin.sock": "",
+		"9090":                   `server.admin.address: invalid address "9090"`,
+		"unix://":                "server.admin.address: unix socket path is empty",
+		":8080":                  "server.admin.address: must differ from the public address",
+	} {
+		cfg := valid
+		cfg.AdminAddress = address
+		if expected == "" {
+			assert.NoError(t, cfg.Validate(), address)
+		} else {
+			assert.ErrorContains(t, cfg.Validate(), expected, address)
+		}
+	}
+}
+
+func TestShutdown_StopsAdminAfterDrain(t *testing.T) {
+	public, publicURL := startServer(t, http.NotFoundHandler())
+	admin, adminURL := startServer(t, http.NotFoundHandler())
+
+	err := Shutdown(context.Background(), ShutdownOptions{
+		Server:  public,
+		Admin:   admin,
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	for _, url := range []string{publicURL, adminURL} {
+		_, err := http.Get(url)
+		assert.Error(t, err, url)
+	}
+}
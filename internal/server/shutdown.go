@@ -0,0 +1,125 @@
+// Package server runs the HTTP server: listener setup, in-flight request
+// tracking and the graceful shutdown sequence.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"{{MODULE_NAME}}/internal/health"
+	"{{MODULE_NAME}}/internal/lifecycle"
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// shutdownCheckName names the readiness failure reported while draining
+const shutdownCheckName = "shutdown"
+
+// ShutdownOptions configures Shutdown
+type ShutdownOptions struct {
+	Server *http.Server
+	// Admin is the admin listener's server, stopped after Server so health
+	// and debug endpoints stay reachable while traffic drains; optional
+	Admin *http.Server
+	// Readiness is marked unready before draining; optional
+	Readiness *health.Runner
+	// DrainDelay gives load balancers time to observe the failing readiness probe
+	// before the listener closes
+	DrainDelay time.Duration
+	// Timeout bounds the wait for in-flight requests; remaining connections are closed
+	Timeout time.Duration
+	// InFlight reports the number of requests still being served; optional
+	InFlight *Tracker
+	// Components are stopped once in-flight requests completed; optional
+	Components *lifecycle.Manager
+	// ProgressInterval is how often the in-flight count is logged; one second when zero
+	ProgressInterval time.Duration
+}
+
+// Shutdown stops the server in order: fail readiness, wait DrainDelay, stop
+// accepting connections, wait for in-flight requests, stop the components,
+// then stop the admin listener. Callers flush the logger afterwards.
+func Shutdown(ctx context.Context, opts ShutdownOptions) error {
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = time.Second
+	}
+
+	if opts.Readiness != nil {
+		opts.Readiness.MarkUnready(shutdownCheckName, "server is shutting down")
+		logging.Info("Readiness probe now failing")
+	}
+
+	if opts.DrainDelay > 0 {
+		logging.Info("Waiting %s for load balancers to stop routing traffic", opts.DrainDelay)
+		select {
+		case <-time.After(opts.DrainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	var errs []error
+	if err := shutdownServer(ctx, opts); err != nil {
+		errs = append(errs, err)
+	}
+	if opts.Components != nil {
+		if err := opts.Components.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if opts.Admin != nil {
+		if err := shutdownAdmin(ctx, opts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shutdownServer closes the listener and waits for in-flight requests,
+// logging how many remain until they finish or the timeout expires
+func shutdownServer(ctx context.Context, opts ShutdownOptions) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	logging.Info("Stopping listener with %d requests in flight", opts.InFlight.count())
+
+	done := make(chan error, 1)
+	go func() { done <- opts.Server.Shutdown(ctx) }()
+
+	ticker := time.NewTicker(opts.ProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				logging.Error("Forcing %d in-flight requests closed: %v", opts.InFlight.count(), err)
+				_ = opts.Server.Close()
+				return fmt.Errorf("server shutdown: %w", err)
+			}
+			logging.Info("All in-flight requests completed")
+			return nil
+		case <-ticker.C:
+			logging.Info("Waiting for %d in-flight requests", opts.InFlight.count())
+		}
+	}
+}
+
+// shutdownAdmin stops the admin server, closing connections still open at the timeout
+func shutdownAdmin(ctx context.Context, opts ShutdownOptions) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	if err := opts.Admin.Shutdown(ctx); err != nil {
+		_ = opts.Admin.Close()
+		return fmt.Errorf("admin server shutdown: %w", err)
+	}
+	logging.Info("Admin listener stopped")
+	return nil
+}
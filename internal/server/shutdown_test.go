@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"{{MODULE_NAME}}/internal/health"
+	"{{MODULE_NAME}}/internal/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer serves handler on a loopback listener and returns its base URL
+func startServer(t *testing.T, handler http.Handler) (*http.Server, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &http.Server{Handler: handler}
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return srv, "http://" + listener.Addr().String()
+}
+
+func TestShutdown_Sequence(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	readiness := health.NewRunner(health.Options{Checks: func() []health.Check { return nil }})
+	tracker := NewTracker()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	srv, url := startServer(t, tracker.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		record("request finished")
+		_, _ = io.WriteString(w, "done")
+	})))
+
+	// Start a slow request before shutting down
+	responses := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			responses <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		responses <- string(body)
+	}()
+	<-started
+	assert.Equal(t, int64(1), tracker.Count())
+
+	stopOnly := func(name string) lifecycle.Registration {
+		return lifecycle.Registration{
+			Name:      name,
+			Component: lifecycle.Funcs{OnStop: func(ctx context.Context) error { record("stop " + name); return nil }},
+		}
+	}
+
+	components, err := lifecycle.New(lifecycle.Options{Components: []lifecycle.Registration{
+		stopOnly("database"), stopOnly("cache"), stopOnly("consumer"),
+	}})
+	require.NoError(t, err)
+	require.NoError(t, components.Start(context.Background()))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Shutdown(context.Background(), ShutdownOptions{
+			Server:           srv,
+			Readiness:        readiness,
+			DrainDelay:       50 * time.Millisecond,
+			Timeout:          5 * time.Second,
+			InFlight:         tracker,
+			Components:       components,
+			ProgressInterval: 10 * time.Millisecond,
+		})
+	}()
+
+	// Readiness fails while the listener is still open
+	require.Eventually(t, func() bool {
+		return readiness.Run(context.Background(), health.ProbeReady).Status == health.StatusUnhealthy
+	}, time.Second, 5*time.Millisecond)
+	record("readiness failed")
+
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	require.NoError(t, <-done)
+	assert.Equal(t, "done", <-responses)
+	assert.Equal(t, int64(0), tracker.Count())
+	assert.Equal(t, []string{"readiness failed", "request finished", "stop consumer", "stop cache", "stop database"}, events)
+
+	_, err = http.Get(url)
+	assert.Error(t, err, "listener should be closed")
+}
+
+func TestShutdown_TimeoutClosesConnections(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	srv, url := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	go func() {
+		if resp, err := http.Get(url); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	err := Shutdown(context.Background(), ShutdownOptions{
+		Server:  srv,
+		Timeout: 50 * time.Millisecond,
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
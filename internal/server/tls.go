@@ -0,0 +1,289 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"{{MODULE_NAME}}/internal/config"
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// TLS cipher policies
+const (
+	// CipherPolicyDefault uses the Go standard library's cipher suite selection
+	CipherPolicyDefault = "default"
+	// CipherPolicyModern limits TLS 1.2 to ECDHE key exchange with AEAD ciphers.
+	// TLS 1.3 suites are not configurable and always allowed.
+	CipherPolicyModern = "modern"
+)
+
+// Client certificate policies
+const (
+	ClientAuthNone     = "none"     // Do not ask for client certificates
+	ClientAuthOptional = "optional" // Verify a client certificate when one is sent
+	ClientAuthRequire  = "require"  // Reject clients without a valid certificate
+)
+
+// modernCipherSuites are the TLS 1.2 suites allowed by CipherPolicyModern
+var modernCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+// tlsVersions maps server.tls.min_version values to protocol versions
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSConfig holds the server.tls section. TLS is enabled when CertFile is set.
+type TLSConfig struct {
+	CertFile       string
+	KeyFile        string
+	MinVersion     string
+	CipherPolicy   string
+	ClientCAFile   string
+	ClientAuth     string
+	ReloadInterval time.Duration
+}
+
+// Enabled reports whether the server should serve HTTPS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// loadTLSConfig reads the server.tls section
+func loadTLSConfig(duration func(string) time.Duration) TLSConfig {
+	cfg := TLSConfig{
+		CertFile:       config.GetString(config.ServerTLSCertFileKey),
+		KeyFile:        config.GetString(config.ServerTLSKeyFileKey),
+		MinVersion:     config.GetString(config.ServerTLSMinVersionKey),
+		CipherPolicy:   config.GetString(config.ServerTLSCipherPolicyKey),
+		ClientCAFile:   config.GetString(config.ServerTLSClientCAFileKey),
+		ClientAuth:     config.GetString(config.ServerTLSClientAuthKey),
+		ReloadInterval: duration(config.ServerTLSReloadIntervalKey),
+	}
+	if cfg.ClientAuth == "" {
+		cfg.ClientAuth = ClientAuthNone
+		if cfg.ClientCAFile != "" {
+			cfg.ClientAuth = ClientAuthRequire
+		}
+	}
+	return cfg
+}
+
+// validate reports invalid TLS settings
+func (c TLSConfig) validate() []error {
+	var errs []error
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		errs = append(errs, fmt.Errorf("%s and %s must be set together", config.ServerTLSCertFileKey, config.ServerTLSKeyFileKey))
+	}
+	if _, ok := tlsVersions[c.MinVersion]; !ok {
+		errs = append(errs, fmt.Errorf("%s: unsupported version %q, expected 1.2 or 1.3", config.ServerTLSMinVersionKey, c.MinVersion))
+	}
+	if c.CipherPolicy != CipherPolicyDefault && c.CipherPolicy != CipherPolicyModern {
+		errs = append(errs, fmt.Errorf("%s: unknown policy %q, expected default or modern", config.ServerTLSCipherPolicyKey, c.CipherPolicy))
+	}
+	switch c.ClientAuth {
+	case ClientAuthNone:
+	case ClientAuthOptional, ClientAuthRequire:
+		if c.ClientCAFile == "" {
+			errs = append(errs, fmt.Errorf("%s: %q requires %s", config.ServerTLSClientAuthKey, c.ClientAuth, config.ServerTLSClientCAFileKey))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("%s: unknown mode %q, expected none, optional or require", config.ServerTLSClientAuthKey, c.ClientAuth))
+	}
+	if c.ClientCAFile != "" && !c.Enabled() {
+		errs = append(errs, fmt.Errorf("%s requires %s", config.ServerTLSClientCAFileKey, config.ServerTLSCertFileKey))
+	}
+	if c.ReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("%s: must not be negative", config.ServerTLSReloadIntervalKey))
+	}
+	return errs
+}
+
+// NewTLSConfig builds the server's tls.Config. Certificates and client CAs
+// come from reloader, so each handshake uses the files most recently loaded.
+func (c TLSConfig) NewTLSConfig(reloader *CertReloader) *tls.Config {
+	base := &tls.Config{
+		MinVersion:     tlsVersions[c.MinVersion],
+		GetCertificate: reloader.GetCertificate,
+		// Set explicitly because configs returned by GetConfigForClient do not
+		// inherit the protocols http.Server adds to its own copy
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if c.CipherPolicy == CipherPolicyModern {
+		base.CipherSuites = modernCipherSuites
+	}
+	switch c.ClientAuth {
+	case ClientAuthOptional:
+		base.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		base.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if base.ClientAuth == tls.NoClientCert {
+		return base
+	}
+
+	// Resolve the client CA pool per handshake so reloads apply to new connections
+	cfg := base.Clone()
+	cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		perConn := base.Clone()
+		perConn.ClientCAs = reloader.ClientCAs()
+		return perConn, nil
+	}
+	return cfg
+}
+
+// CertReloader serves a certificate and client CA pool loaded from files,
+// replacing them when the files change. Existing connections keep the
+// certificate they negotiated.
+type CertReloader struct {
+	certFile, keyFile, caFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	digest    []byte
+	failed    []byte // digest of the last content that failed to load
+}
+
+// NewCertReloader loads the certificate, key and optional client CA bundle
+func NewCertReloader(certFile, keyFile, caFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the current certificate; it implements tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// ClientCAs returns the current client CA pool, or nil without a CA file
+func (r *CertReloader) ClientCAs() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clientCAs
+}
+
+// Reload loads the files again if their content changed. It reports whether
+// new material was installed. On error the previous certificate stays in use;
+// each failing file content is reported once.
+func (r *CertReloader) Reload() (bool, error) {
+	certPEM, err := os.ReadFile(r.certFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read TLS certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read TLS key: %w", err)
+	}
+	var caPEM []byte
+	if r.caFile != "" {
+		if caPEM, err = os.ReadFile(r.caFile); err != nil {
+			return false, fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+	}
+
+	digest := sha256.New()
+	for _, data := range [][]byte{certPEM, keyPEM, caPEM} {
+		sum := sha256.Sum256(data)
+		digest.Write(sum[:])
+	}
+	sum := digest.Sum(nil)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if bytes.Equal(sum, r.digest) || bytes.Equal(sum, r.failed) {
+		return false, nil
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		r.failed = sum
+		return false, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			r.failed = sum
+			return false, fmt.Errorf("no certificates found in client CA bundle %s", r.caFile)
+		}
+	}
+
+	r.cert, r.clientCAs, r.digest = &cert, pool, sum
+	return true, nil
+}
+
+// Watch reloads the files every interval until ctx is done
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.Reload()
+			switch {
+			case err != nil:
+				logging.Warn("TLS certificate reload failed, keeping the current certificate: %v", err)
+			case changed:
+				logging.Info("Reloaded TLS certificate from %s", r.certFile)
+			}
+		}
+	}
+}
+
+// ConfigureTLS enables TLS on srv when a certificate is configured and
+// reloads the certificate files every ReloadInterval until ctx is done
+func (c Config) ConfigureTLS(ctx context.Context, srv *http.Server) error {
+	if !c.TLS.Enabled() {
+		return nil
+	}
+
+	reloader, err := NewCertReloader(c.TLS.CertFile, c.TLS.KeyFile, c.TLS.ClientCAFile)
+	if err != nil {
+		return err
+	}
+	srv.TLSConfig = c.TLS.NewTLSConfig(reloader)
+	if c.TLS.ReloadInterval > 0 {
+		go reloader.Watch(ctx, c.TLS.ReloadInterval)
+	}
+	logging.Info("TLS enabled (minimum version %s, cipher policy %s, client certificates %s)", c.TLS.MinVersion, c.TLS.CipherPolicy, c.TLS.ClientAuth)
+	return nil
+}
+
+// Serve serves srv on listener, using TLS when srv.TLSConfig is set. When
+// srv accepts h2c, HTTP/1.1 requests may also upgrade to HTTP/2.
+func Serve(srv *http.Server, listener net.Listener) error {
+	if srv.TLSConfig != nil {
+		return srv.ServeTLS(listener, "", "")
+	}
+	if acceptsH2C(srv) {
+		h2cListener := newH2CListener(listener)
+		srv.Handler = h2cUpgrade(h2cListener, srv.Handler)
+		listener = h2cListener
+	}
+	return srv.Serve(listener)
+}
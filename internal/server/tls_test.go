@@ -0,0 +1,263 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"{{MODULE_NAME}}/internal/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA is a self-signed certificate authority for tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// newTestCA creates a self-signed CA
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue signs a leaf certificate and returns its PEM encoded certificate and key
+func (ca *testCA) issue(t *testing.T, commonName string, serial int64, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Example"}},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile writes data to name in dir and returns the path
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+// serveTLS serves handler with cfg on a loopback listener and returns its address
+func serveTLS(t *testing.T, cfg Config, handler http.Handler) string {
+	srv := cfg.NewHTTPServer(handler)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	require.NoError(t, cfg.ConfigureTLS(ctx, srv))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = Serve(srv, listener) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return listener.Addr().String()
+}
+
+// tlsClient returns an HTTP client trusting ca and presenting certs
+func tlsClient(ca *testCA, certs ...tls.Certificate) *http.Client {
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca.pem)
+	return &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: pool, Certificates: certs},
+		ForceAttemptHTTP2: true,
+	}}
+}
+
+// testTLSConfig returns a valid server config using the given files
+func testTLSConfig(certFile, keyFile string) Config {
+	return Config{
+		Port:           "0",
+		MaxHeaderBytes: 1 << 20,
+		KeepAlives:     true,
+		TLS: TLSConfig{
+			CertFile:     certFile,
+			KeyFile:      keyFile,
+			MinVersion:   "1.2",
+			CipherPolicy: CipherPolicyModern,
+			ClientAuth:   ClientAuthNone,
+		},
+	}
+}
+
+func TestTLS_ServesHTTPS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "server CA")
+	certPEM, keyPEM := ca.issue(t, "localhost", 10, x509.ExtKeyUsageServerAuth)
+	cfg := testTLSConfig(writeFile(t, dir, "tls.crt", certPEM), writeFile(t, dir, "tls.key", keyPEM))
+	require.NoError(t, cfg.Validate())
+
+	addr := serveTLS(t, cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, identified := types.ClientIdentityFromRequest(r)
+		assert.False(t, identified)
+		_, _ = io.WriteString(w, r.Proto)
+	}))
+
+	resp, err := tlsClient(ca).Get("https://" + addr)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "HTTP/2.0", string(body))
+	assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+
+	// Clients limited to TLS 1.2 must negotiate a modern suite
+	client := tlsClient(ca)
+	client.Transport.(*http.Transport).TLSClientConfig.MaxVersion = tls.VersionTLS12
+	client.Transport.(*http.Transport).TLSClientConfig.CipherSuites = []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA}
+	_, err = client.Get("https://" + addr)
+	assert.Error(t, err)
+}
+
+func TestTLS_MinVersion(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "server CA")
+	certPEM, keyPEM := ca.issue(t, "localhost", 10, x509.ExtKeyUsageServerAuth)
+	cfg := testTLSConfig(writeFile(t, dir, "tls.crt", certPEM), writeFile(t, dir, "tls.key", keyPEM))
+	cfg.TLS.MinVersion = "1.3"
+	addr := serveTLS(t, cfg, http.NotFoundHandler())
+
+	client := tlsClient(ca)
+	client.Transport.(*http.Transport).TLSClientConfig.MaxVersion = tls.VersionTLS12
+	_, err := client.Get("https://" + addr)
+	assert.ErrorContains(t, err, "protocol version")
+}
+
+func TestTLS_ClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newTestCA(t, "server CA")
+	clientCA := newTestCA(t, "client CA")
+	otherCA := newTestCA(t, "other CA")
+	certPEM, keyPEM := serverCA.issue(t, "localhost", 10, x509.ExtKeyUsageServerAuth)
+
+	cfg := testTLSConfig(writeFile(t, dir, "tls.crt", certPEM), writeFile(t, dir, "tls.key", keyPEM))
+	cfg.TLS.ClientCAFile = writeFile(t, dir, "clients.pem", clientCA.pem)
+	cfg.TLS.ClientAuth = ClientAuthRequire
+	require.NoError(t, cfg.Validate())
+
+	addr := serveTLS(t, cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := types.ClientIdentityFromRequest(r)
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, identity.CommonName+" "+identity.Subject+" "+identity.SerialNumber)
+	}))
+
+	clientCert := func(ca *testCA, name string) tls.Certificate {
+		certPEM, keyPEM := ca.issue(t, name, 42, x509.ExtKeyUsageClientAuth)
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		require.NoError(t, err)
+		return cert
+	}
+
+	resp, err := tlsClient(serverCA, clientCert(clientCA, "billing")).Get("https://" + addr)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "billing CN=billing,O=Example 2a", string(body))
+
+	_, err = tlsClient(serverCA).Get("https://" + addr)
+	assert.Error(t, err, "client without a certificate must be rejected")
+
+	_, err = tlsClient(serverCA, clientCert(otherCA, "intruder")).Get("https://" + addr)
+	assert.Error(t, err, "certificate from an untrusted CA must be rejected")
+}
+
+func TestCertReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "server CA")
+	certPEM, keyPEM := ca.issue(t, "localhost", 1, x509.ExtKeyUsageServerAuth)
+	certFile := writeFile(t, dir, "tls.crt", certPEM)
+	keyFile := writeFile(t, dir, "tls.key", keyPEM)
+
+	cfg := testTLSConfig(certFile, keyFile)
+	cfg.TLS.ReloadInterval = 10 * time.Millisecond
+	addr := serveTLS(t, cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serial := func(client *http.Client) int64 {
+		resp, err := client.Get("https://" + addr)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	client := tlsClient(ca)
+	assert.Equal(t, int64(1), serial(client))
+
+	// A half-written pair keeps the old certificate
+	certPEM, keyPEM = ca.issue(t, "localhost", 2, x509.ExtKeyUsageServerAuth)
+	writeFile(t, dir, "tls.crt", certPEM)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(1), serial(tlsClient(ca)))
+
+	writeFile(t, dir, "tls.key", keyPEM)
+	assert.Eventually(t, func() bool { return serial(tlsClient(ca)) == 2 }, 2*time.Second, 20*time.Millisecond)
+
+	// The existing keep-alive connection is not dropped
+	assert.Equal(t, int64(1), serial(client))
+}
+
+func TestTLSConfig_Validate(t *testing.T) {
+	cfg := TLSConfig{
+		KeyFile:        "tls.key",
+		MinVersion:     "1.0",
+		CipherPolicy:   "legacy",
+		ClientCAFile:   "ca.pem",
+		ClientAuth:     "always",
+		ReloadInterval: -time.Second,
+	}
+
+	var messages []string
+	for _, err := range cfg.validate() {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		"server.tls.cert_file and server.tls.key_file must be set together",
+		`server.tls.min_version: unsupported version "1.0", expected 1.2 or 1.3`,
+		`server.tls.cipher_policy: unknown policy "legacy", expected default or modern`,
+		`server.tls.client_auth: unknown mode "always", expected none, optional or require`,
+		"server.tls.client_ca_file requires server.tls.cert_file",
+		"server.tls.reload_interval: must not be negative",
+	}, messages)
+}
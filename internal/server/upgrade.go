@@ -0,0 +1,34 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"time"
+)
+
+// DefaultUpgradeTimeout bounds the wait for a new process to report ready
+const DefaultUpgradeTimeout = 30 * time.Second
+
+// upgradeReadyFDEnv names the descriptor a new process writes to once it
+// serves. It also marks LISTEN_FDS as set by the previous process.
+const upgradeReadyFDEnv = "UPGRADE_READY_FD"
+
+// publicSocketName names the public socket passed to the new process
+const publicSocketName = "public"
+
+// ErrUpgradeUnsupported is returned by Upgrade on platforms other than Linux
+var ErrUpgradeUnsupported = errors.New("binary upgrade is only supported on Linux")
+
+// UpgradeOptions configures Upgrade
+type UpgradeOptions struct {
+	// Public is the listener handed to the new process
+	Public net.Listener
+	// Admin is the admin listener handed over as AdminSocketName; optional
+	Admin net.Listener
+	// Timeout bounds the wait for the new process; DefaultUpgradeTimeout when zero
+	Timeout time.Duration
+	// Path and Args start the new process; the running executable and its
+	// arguments when empty
+	Path string
+	Args []string
+}
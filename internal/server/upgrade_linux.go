@@ -0,0 +1,163 @@
+//go:build linux
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"{{MODULE_NAME}}/internal/logging"
+)
+
+// NotifyUpgrade relays the upgrade signal, SIGUSR2, to c
+func NotifyUpgrade(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGUSR2)
+}
+
+// Upgrade starts a new copy of the server that inherits the listening
+// sockets and waits until it reports ready via NotifyUpgradeReady. The
+// caller keeps serving and, on success, drains and exits; on failure the
+// new process is killed and the caller carries on as before.
+func Upgrade(ctx context.Context, opts UpgradeOptions) (*os.Process, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultUpgradeTimeout
+	}
+	if opts.Path == "" {
+		path, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find executable: %w", err)
+		}
+		opts.Path, opts.Args = path, os.Args[1:]
+	}
+
+	var files []*os.File
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+	var names []string
+	for _, socket := range []struct {
+		name     string
+		listener net.Listener
+	}{
+		{publicSocketName, opts.Public},
+		{AdminSocketName, opts.Admin},
+	} {
+		if socket.listener == nil {
+			continue
+		}
+		file, err := listenerFile(socket.listener)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hand over %s listener: %w", socket.name, err)
+		}
+		files = append(files, file)
+		names = append(names, socket.name)
+	}
+
+	ready, readyWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer ready.Close()
+	socketCount := len(files)
+	files = append(files, readyWriter)
+
+	cmd := exec.Command(opts.Path, opts.Args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(upgradeEnviron(),
+		listenFDsEnv+"="+strconv.Itoa(socketCount),
+		listenFDNamesEnv+"="+strings.Join(names, ":"),
+		upgradeReadyFDEnv+"="+strconv.Itoa(listenFDsStart+socketCount),
+	)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start new process: %w", err)
+	}
+	// Only the new process holds the write end now, so its exit ends the read
+	readyWriter.Close()
+	files = files[:socketCount]
+	logging.Info("Started new process %d; waiting up to %s for it to become ready", cmd.Process.Pid, opts.Timeout)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := ready.Read(make([]byte, 1))
+		result <- err
+	}()
+
+	timer := time.NewTimer(opts.Timeout)
+	defer timer.Stop()
+	select {
+	case err = <-result:
+		if err == nil {
+			return cmd.Process, nil
+		}
+		if err == io.EOF {
+			return nil, fmt.Errorf("new process exited before it was ready: %w", cmd.Wait())
+		}
+	case <-timer.C:
+		err = fmt.Errorf("new process was not ready within %s", opts.Timeout)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	cmd.Process.Kill()
+	cmd.Wait()
+	return nil, err
+}
+
+// NotifyUpgradeReady tells the previous process that this one serves, so it
+// can drain and exit. It does nothing unless the process was started by Upgrade.
+func NotifyUpgradeReady() error {
+	value := os.Getenv(upgradeReadyFDEnv)
+	if value == "" {
+		return nil
+	}
+	os.Unsetenv(upgradeReadyFDEnv)
+
+	fd, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", upgradeReadyFDEnv, value)
+	}
+	file := os.NewFile(uintptr(fd), upgradeReadyFDEnv)
+	defer file.Close()
+	_, err = file.Write([]byte{1})
+	return err
+}
+
+// listenerFile duplicates the descriptor of a listener. Unix listeners stop
+// removing their socket file on close, since the new process still uses it.
+func listenerFile(listener net.Listener) (*os.File, error) {
+	switch l := listener.(type) {
+	case *net.TCPListener:
+		return l.File()
+	case *net.UnixListener:
+		l.SetUnlinkOnClose(false)
+		return l.File()
+	default:
+		return nil, fmt.Errorf("unsupported listener type %T", listener)
+	}
+}
+
+// upgradeEnviron returns the environment without variables left over from a
+// previous handover
+func upgradeEnviron() []string {
+	var env []string
+	for _, entry := range os.Environ() {
+		key, _, _ := strings.Cut(entry, "=")
+		switch key {
+		case listenPIDEnv, listenFDsEnv, listenFDNamesEnv, upgradeReadyFDEnv:
+			continue
+		}
+		env = append(env, entry)
+	}
+	return env
+}
@@ -0,0 +1,117 @@
+//go:build linux
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeHelperEnv selects what TestUpgradeHelperProcess does
+const upgradeHelperEnv = "GO_UPGRADE_HELPER"
+
+// TestUpgradeHelperProcess is the new process the upgrade tests start
+func TestUpgradeHelperProcess(t *testing.T) {
+	switch os.Getenv(upgradeHelperEnv) {
+	case "":
+		return
+	case "exit":
+		os.Exit(3)
+	case "hang":
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+
+	for _, socket := range []struct {
+		admin bool
+		body  string
+	}{{false, "child"}, {true, "child admin"}} {
+		listener, err := takeActivated(socket.admin)
+		if err != nil || listener == nil {
+			os.Exit(1)
+		}
+		body := socket.body
+		srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, body)
+		})}
+		go func() { _ = srv.Serve(listener) }()
+	}
+	if err := NotifyUpgradeReady(); err != nil {
+		os.Exit(1)
+	}
+	select {}
+}
+
+// startUpgrade upgrades to the test binary running TestUpgradeHelperProcess in mode
+func startUpgrade(t *testing.T, mode string, opts UpgradeOptions) (*os.Process, error) {
+	t.Setenv(upgradeHelperEnv, mode)
+	opts.Path, opts.Args = os.Args[0], []string{"-test.run=^TestUpgradeHelperProcess$"}
+	return Upgrade(context.Background(), opts)
+}
+
+func TestUpgrade_HandsOverListeners(t *testing.T) {
+	public, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "admin.sock")
+	admin, err := Config{}.listen("unix://" + path)
+	require.NoError(t, err)
+
+	parent := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "parent")
+	})}
+	go func() { _ = parent.Serve(public) }()
+	go func() { _ = parent.Serve(admin) }()
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	url := "http://" + public.Addr().String()
+	assert.Equal(t, "parent", get(t, client, url))
+
+	child, err := startUpgrade(t, "serve", UpgradeOptions{Public: public, Admin: admin, Timeout: 10 * time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		child.Kill()
+		child.Wait()
+	})
+
+	// The sockets outlive the parent, including the unix socket file
+	require.NoError(t, parent.Close())
+	assert.Equal(t, "child", get(t, client, url))
+	assert.Equal(t, "child admin", get(t, unixClient(path), "http://admin/"))
+}
+
+func TestUpgrade_ChildExitsEarly(t *testing.T) {
+	public, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer public.Close()
+
+	_, err = startUpgrade(t, "exit", UpgradeOptions{Public: public, Timeout: 10 * time.Second})
+	assert.ErrorContains(t, err, "new process exited before it was ready: exit status 3")
+}
+
+func TestUpgrade_Timeout(t *testing.T) {
+	public, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer public.Close()
+
+	start := time.Now()
+	_, err = startUpgrade(t, "hang", UpgradeOptions{Public: public, Timeout: 200 * time.Millisecond})
+	assert.ErrorContains(t, err, "new process was not ready within 200ms")
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestUpgrade_UnsupportedListener(t *testing.T) {
+	_, err := Upgrade(context.Background(), UpgradeOptions{Public: tlsListener{}})
+	assert.ErrorContains(t, err, "failed to hand over public listener: unsupported listener type")
+}
+
+// tlsListener stands in for a listener that has no file descriptor
+type tlsListener struct{ net.Listener }
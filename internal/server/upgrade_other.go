@@ -0,0 +1,21 @@
+//go:build !linux
+
+package server
+
+import (
+	"context"
+	"os"
+)
+
+// NotifyUpgrade does nothing: binary upgrades are only supported on Linux
+func NotifyUpgrade(c chan<- os.Signal) {}
+
+// Upgrade returns ErrUpgradeUnsupported
+func Upgrade(ctx context.Context, opts UpgradeOptions) (*os.Process, error) {
+	return nil, ErrUpgradeUnsupported
+}
+
+// NotifyUpgradeReady does nothing: processes are never started by Upgrade
+func NotifyUpgradeReady() error {
+	return nil
+}
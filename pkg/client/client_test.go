@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGethealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/health", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"HEALTHY"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithHeader("Authorization", "token"))
+	require.NoError(t, err)
+
+	resp, err := c.Gethealth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "HEALTHY", resp.Status)
+}
+
+func TestRetriesTransientFailures(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"HEALTHY"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithRetries(2, time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = c.Gethealth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestAPIErrorDecoded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":true,"message":"bad input","status":400}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	require.NoError(t, err)
+
+	_, err = c.Gethealth(context.Background())
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "bad input", apiErr.Response.Message)
+	assert.Equal(t, "api error 400: bad input", apiErr.Error())
+}
+
+func TestContextCancelsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithRetries(5, time.Hour))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Gethealth(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPathParametersEscapedOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/users/a%20b%2Fc%25d", r.URL.EscapedPath())
+		assert.Equal(t, "/api/users/a b/c%d", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL + "/api/")
+	require.NoError(t, err)
+
+	err = c.do(context.Background(), http.MethodGet, "/users/"+url.PathEscape("a b/c%d"), nil, nil, nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,50 @@
+// Code generated by cmd/generate-client. DO NOT EDIT.
+
+package client
+
+import "context"
+
+// Gethealth calls GET /health: Health check endpoint returning service status
+func (c *Client) Gethealth(ctx context.Context) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.do(ctx, "GET", "/health", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GethealthLive calls GET /health/live: Liveness probe; fails only when the process must be restarted
+func (c *Client) GethealthLive(ctx context.Context) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.do(ctx, "GET", "/health/live", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GethealthReady calls GET /health/ready: Readiness probe; fails while the service should not receive traffic
+func (c *Client) GethealthReady(ctx context.Context) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.do(ctx, "GET", "/health/ready", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GethealthStartup calls GET /health/startup: Startup probe; fails until the service has finished starting
+func (c *Client) GethealthStartup(ctx context.Context) (*HealthResponse, error) {
+	var out HealthResponse
+	if err := c.do(ctx, "GET", "/health/startup", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Getversion calls GET /version: Build information of the running server
+func (c *Client) Getversion(ctx context.Context) (*VersionResponse, error) {
+	var out VersionResponse
+	if err := c.do(ctx, "GET", "/version", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
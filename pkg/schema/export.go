@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Draft2020 is the $schema URI of JSON Schema draft 2020-12
+const Draft2020 = "https://json-schema.org/draft/2020-12/schema"
+
+// Export builds one draft 2020-12 document per named struct type reachable
+// from roots, keyed by file name (<TypeName>.json). Each document has an $id
+// under baseURL and references other named structs by their file name, which
+// resolves against that $id.
+func Export(roots []reflect.Type, baseURL string) (map[string][]byte, error) {
+	named := make(map[string]reflect.Type)
+	for _, root := range roots {
+		if err := collectNamed(root, named); err != nil {
+			return nil, err
+		}
+	}
+
+	gen := New(Options{
+		Dialect: JSONSchema2020,
+		Ref: func(t reflect.Type) (string, bool) {
+			if named[TypeName(t)] != t {
+				return "", false
+			}
+			return FileName(t), true
+		},
+	})
+
+	baseURL = strings.TrimRight(baseURL, "/")
+	files := make(map[string][]byte, len(named))
+	for _, t := range named {
+		doc, err := gen.Schema(t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate schema for %v: %w", t, err)
+		}
+		doc["$schema"] = Draft2020
+		doc["$id"] = baseURL + "/" + FileName(t)
+		doc["title"] = TypeName(t)
+
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode schema for %v: %w", t, err)
+		}
+		files[FileName(t)] = append(data, '\n')
+	}
+	return files, nil
+}
+
+// FileName returns the file a named type's schema is written to
+func FileName(t reflect.Type) string {
+	return TypeName(t) + ".json"
+}
+
+// collectNamed records every named struct type reachable from t by name.
+// Two types sharing a name would share a file, so they are rejected.
+func collectNamed(t reflect.Type, named map[string]reflect.Type) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isTime(t) {
+		return nil
+	}
+
+	if t.Name() != "" {
+		name := TypeName(t)
+		if existing, ok := named[name]; ok {
+			if existing == t {
+				return nil
+			}
+			return fmt.Errorf("types %v and %v both map to %s", existing, t, FileName(t))
+		}
+		named[name] = t
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		if err := collectNamed(field.Type, named); err != nil {
+			return err
+		}
+	}
+	return nil
+}
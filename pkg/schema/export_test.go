@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type team struct {
+	Name    string   `json:"name"`
+	Members []person `json:"members"`
+	Lead    *person  `json:"lead,omitempty"`
+	Meta    struct {
+		Created string `json:"created"`
+	} `json:"meta"`
+}
+
+func TestExport(t *testing.T) {
+	files, err := Export([]reflect.Type{reflect.TypeOf([]team{}), reflect.TypeOf(node{}), reflect.TypeOf("")}, "https://api.example.com/schemas/")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"team.json", "person.json", "address.json", "node.json"}, names)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(files["team.json"], &doc))
+	assert.Equal(t, Draft2020, doc["$schema"])
+	assert.Equal(t, "https://api.example.com/schemas/team.json", doc["$id"])
+	assert.Equal(t, "team", doc["title"])
+
+	props := doc["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "person.json"}, props["members"].(map[string]interface{})["items"])
+	assert.Equal(t, map[string]interface{}{"anyOf": []interface{}{
+		map[string]interface{}{"$ref": "person.json"},
+		map[string]interface{}{"type": "null"},
+	}}, props["lead"])
+	// Anonymous structs have no file of their own and stay inline
+	assert.Equal(t, "object", props["meta"].(map[string]interface{})["type"])
+
+	require.NoError(t, json.Unmarshal(files["person.json"], &doc))
+	assert.Equal(t, map[string]interface{}{"$ref": "address.json"}, doc["properties"].(map[string]interface{})["home"])
+
+	again, err := Export([]reflect.Type{reflect.TypeOf([]team{}), reflect.TypeOf(node{})}, "https://api.example.com/schemas")
+	require.NoError(t, err)
+	assert.Equal(t, files, again, "output must be deterministic")
+}
+
+func TestExport_NameCollision(t *testing.T) {
+	type address struct {
+		Street string `json:"street"`
+	}
+	type holder struct {
+		Mine   address `json:"mine"`
+		Theirs *person `json:"theirs"`
+	}
+	_, err := Export([]reflect.Type{reflect.TypeOf(holder{})}, "https://api.example.com")
+	assert.ErrorContains(t, err, "both map to address.json")
+}
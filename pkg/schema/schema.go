@@ -0,0 +1,273 @@
+// Package schema derives JSON Schemas from Go types by reflection. It reads
+// the json, example, enum and description struct tags, and backs both the
+// OpenAPI generator and the standalone JSON Schema export.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Dialect selects the keywords used where OpenAPI 3.0 and JSON Schema differ
+type Dialect int
+
+const (
+	// OpenAPI30 marks pointers with "nullable": true and uses "example"
+	OpenAPI30 Dialect = iota
+	// JSONSchema2020 adds "null" to the type and uses an "examples" array,
+	// as in JSON Schema draft 2020-12
+	JSONSchema2020
+)
+
+// Options controls schema generation
+type Options struct {
+	// Ref optionally returns a reference for a named struct type. Referenced
+	// types are not inlined; nil inlines every nested struct.
+	Ref func(t reflect.Type) (string, bool)
+	// Dialect of the generated keywords
+	Dialect Dialect
+}
+
+// Generator converts Go types to JSON Schema objects
+type Generator struct {
+	opts Options
+}
+
+// New creates a schema generator
+func New(opts Options) *Generator {
+	return &Generator{opts: opts}
+}
+
+// Schema returns the schema for t. A root struct type is always expanded,
+// even when Options.Ref would reference it.
+func (g *Generator) Schema(t reflect.Type) (map[string]interface{}, error) {
+	return g.schemaForType(t, make(map[reflect.Type]bool), true)
+}
+
+// schemaForType recursively generates schema, handling circular references
+func (g *Generator) schemaForType(t reflect.Type, visited map[reflect.Type]bool, root bool) (map[string]interface{}, error) {
+	// Dereference pointers first
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Handle primitive types immediately (no circular reference issues)
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	}
+
+	if t.Kind() == reflect.Struct && isTime(t) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+	if !root && g.opts.Ref != nil && t.Kind() == reflect.Struct && t.Name() != "" {
+		if ref, ok := g.opts.Ref(t); ok {
+			return map[string]interface{}{"$ref": ref}, nil
+		}
+	}
+
+	// Handle circular references for complex types only
+	if visited[t] {
+		return map[string]interface{}{
+			"type":        "object",
+			"description": fmt.Sprintf("Circular reference to %s", t.String()),
+		}, nil
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.structSchema(t, visited)
+	case reflect.Slice, reflect.Array:
+		elemSchema, err := g.schemaForType(t.Elem(), visited, false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"type":  "array",
+			"items": elemSchema,
+		}, nil
+	case reflect.Map, reflect.Interface:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": true,
+		}, nil
+	default:
+		return map[string]interface{}{
+			"type":        "string",
+			"description": fmt.Sprintf("Unsupported type: %s", t.Kind()),
+		}, nil
+	}
+}
+
+// structSchema generates a schema for a struct type
+func (g *Generator) structSchema(t reflect.Type, visited map[reflect.Type]bool) (map[string]interface{}, error) {
+	properties := make(map[string]interface{})
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Skip unexported fields
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue // Skip fields marked with json:"-"
+		}
+
+		fieldName := field.Name
+		omitempty := false
+		if jsonTag != "" {
+			// Parse json tag (e.g., "field_name,omitempty")
+			parts := strings.Split(jsonTag, ",")
+			if parts[0] != "" {
+				fieldName = parts[0]
+			}
+			for _, part := range parts[1:] {
+				if part == "omitempty" {
+					omitempty = true
+				}
+			}
+		}
+		if !omitempty {
+			required = append(required, fieldName)
+		}
+
+		fieldSchema, err := g.schemaForType(field.Type, visited, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate schema for field %s: %w", field.Name, err)
+		}
+
+		if exampleTag, ok := field.Tag.Lookup("example"); ok {
+			example := ParseExampleTag(exampleTag, field.Type)
+			if g.opts.Dialect == JSONSchema2020 {
+				fieldSchema["examples"] = []interface{}{example}
+			} else {
+				fieldSchema["example"] = example
+			}
+		}
+
+		if description, ok := field.Tag.Lookup("description"); ok {
+			fieldSchema["description"] = description
+		}
+
+		if enumTag, ok := field.Tag.Lookup("enum"); ok {
+			var values []interface{}
+			for _, value := range strings.Split(enumTag, ",") {
+				values = append(values, ParseExampleTag(strings.TrimSpace(value), field.Type))
+			}
+			fieldSchema["enum"] = values
+		}
+
+		// Pointer fields may be null
+		if field.Type.Kind() == reflect.Ptr {
+			fieldSchema = g.nullable(fieldSchema)
+		}
+
+		properties[fieldName] = fieldSchema
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema, nil
+}
+
+// nullable marks a field schema as accepting null in the configured dialect
+func (g *Generator) nullable(schema map[string]interface{}) map[string]interface{} {
+	if g.opts.Dialect == OpenAPI30 {
+		schema["nullable"] = true
+		return schema
+	}
+
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []interface{}{typ, "null"}
+		if enum, ok := schema["enum"].([]interface{}); ok {
+			schema["enum"] = append(enum, nil)
+		}
+		return schema
+	}
+
+	// References cannot carry a type, so allow null alongside them
+	ref, ok := schema["$ref"]
+	if !ok {
+		return schema
+	}
+	delete(schema, "$ref")
+	schema["anyOf"] = []interface{}{
+		map[string]interface{}{"$ref": ref},
+		map[string]interface{}{"type": "null"},
+	}
+	return schema
+}
+
+// ParseExampleTag converts an `example` struct tag to a value of the field's JSON type.
+// Composite types accept a JSON literal; anything unparseable is kept as a string.
+func ParseExampleTag(tag string, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := strconv.ParseInt(tag, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err := strconv.ParseFloat(tag, 64); err == nil {
+			return v
+		}
+	case reflect.Bool:
+		if v, err := strconv.ParseBool(tag); err == nil {
+			return v
+		}
+	case reflect.String:
+		return tag
+	default:
+		var v interface{}
+		if err := json.Unmarshal([]byte(tag), &v); err == nil {
+			return v
+		}
+	}
+	return tag
+}
+
+// TypeName returns a clean name for a type to use as a schema reference.
+// Slices are named after their element type with an "Array" suffix.
+func TypeName(t reflect.Type) string {
+	// Handle array/slice types first
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		return TypeName(t.Elem()) + "Array"
+	}
+
+	// Remove package path, keep only the type name
+	name := t.String()
+	if lastDot := strings.LastIndex(name, "."); lastDot != -1 {
+		name = name[lastDot+1:]
+	}
+	return name
+}
+
+// isTime reports whether t is time.Time, which is encoded as an RFC 3339 string
+func isTime(t reflect.Type) bool {
+	return t.PkgPath() == "time" && t.Name() == "Time"
+}
@@ -0,0 +1,116 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `json:"city" description:"City name"`
+}
+
+type person struct {
+	Name     string    `json:"name" example:"Ada"`
+	Role     string    `json:"role" enum:"admin,member"`
+	Nickname *string   `json:"nickname,omitempty"`
+	Home     address   `json:"home"`
+	Work     *address  `json:"work,omitempty"`
+	Born     time.Time `json:"born"`
+	Tags     []string  `json:"tags,omitempty"`
+	Extra    map[string]interface{}
+	Ignored  string `json:"-"`
+	internal string
+}
+
+type node struct {
+	Value    int     `json:"value"`
+	Children []*node `json:"children"`
+}
+
+func TestSchema_Inline(t *testing.T) {
+	s, err := New(Options{}).Schema(reflect.TypeOf(person{}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "object", s["type"])
+	assert.Equal(t, []string{"name", "role", "home", "born", "Extra"}, s["required"])
+
+	props := s["properties"].(map[string]interface{})
+	assert.NotContains(t, props, "Ignored")
+	assert.NotContains(t, props, "internal")
+	assert.Equal(t, map[string]interface{}{"type": "string", "example": "Ada"}, props["name"])
+	assert.Equal(t, []interface{}{"admin", "member"}, props["role"].(map[string]interface{})["enum"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "nullable": true}, props["nickname"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, props["born"])
+	assert.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": true}, props["Extra"])
+
+	// A type used twice is expanded both times rather than reported as circular
+	home := props["home"].(map[string]interface{})
+	work := props["work"].(map[string]interface{})
+	assert.Equal(t, "City name", home["properties"].(map[string]interface{})["city"].(map[string]interface{})["description"])
+	assert.Equal(t, home["properties"], work["properties"])
+	assert.Equal(t, true, work["nullable"])
+}
+
+func TestSchema_Circular(t *testing.T) {
+	s, err := New(Options{}).Schema(reflect.TypeOf(node{}))
+	require.NoError(t, err)
+
+	children := s["properties"].(map[string]interface{})["children"].(map[string]interface{})
+	assert.Equal(t, "Circular reference to schema.node", children["items"].(map[string]interface{})["description"])
+}
+
+func TestSchema_JSONSchema2020(t *testing.T) {
+	gen := New(Options{
+		Dialect: JSONSchema2020,
+		Ref: func(t reflect.Type) (string, bool) {
+			return TypeName(t) + ".json", t == reflect.TypeOf(address{}) || t == reflect.TypeOf(node{})
+		},
+	})
+
+	s, err := gen.Schema(reflect.TypeOf(person{}))
+	require.NoError(t, err)
+	props := s["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "address.json"}, props["home"])
+	assert.Equal(t, map[string]interface{}{"anyOf": []interface{}{
+		map[string]interface{}{"$ref": "address.json"},
+		map[string]interface{}{"type": "null"},
+	}}, props["work"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}}, props["nickname"])
+	assert.Equal(t, []interface{}{"Ada"}, props["name"].(map[string]interface{})["examples"])
+
+	// The root is expanded even when it could be referenced; recursion becomes a $ref
+	s, err = gen.Schema(reflect.TypeOf(node{}))
+	require.NoError(t, err)
+	children := s["properties"].(map[string]interface{})["children"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "node.json"}, children["items"])
+}
+
+func TestSchema_NullableEnum(t *testing.T) {
+	type choice struct {
+		Level *int `json:"level" enum:"1,2"`
+	}
+	s, err := New(Options{Dialect: JSONSchema2020}).Schema(reflect.TypeOf(choice{}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"type": []interface{}{"integer", "null"},
+		"enum": []interface{}{int64(1), int64(2), nil},
+	}, s["properties"].(map[string]interface{})["level"])
+}
+
+func TestTypeName(t *testing.T) {
+	assert.Equal(t, "person", TypeName(reflect.TypeOf(person{})))
+	assert.Equal(t, "personArray", TypeName(reflect.TypeOf([]person{})))
+	assert.Equal(t, "string", TypeName(reflect.TypeOf("")))
+}
+
+func TestParseExampleTag(t *testing.T) {
+	assert.Equal(t, int64(3), ParseExampleTag("3", reflect.TypeOf(0)))
+	assert.Equal(t, 1.5, ParseExampleTag("1.5", reflect.TypeOf(0.0)))
+	assert.Equal(t, true, ParseExampleTag("true", reflect.TypeOf(new(bool))))
+	assert.Equal(t, []interface{}{"a"}, ParseExampleTag(`["a"]`, reflect.TypeOf([]string{})))
+	assert.Equal(t, "oops", ParseExampleTag("oops", reflect.TypeOf(0)))
+}
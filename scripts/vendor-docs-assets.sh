@@ -0,0 +1,28 @@
+#!/bin/bash
+
+set -e
+
+# Downloads the Swagger UI and ReDoc bundles that are embedded into the
+# server binary. Run this when bumping versions and commit the results so
+# builds never need network access to serve the documentation UIs.
+
+SWAGGER_UI_VERSION="${SWAGGER_UI_VERSION:-5.9.0}"
+REDOC_VERSION="${REDOC_VERSION:-2.1.3}"
+
+ASSETS_DIR="$(cd "$(dirname "$0")/.." && pwd)/internal/api/handler/assets"
+
+echo "📦 Vendoring documentation assets into $ASSETS_DIR"
+
+mkdir -p "$ASSETS_DIR/swagger-ui" "$ASSETS_DIR/redoc"
+
+for file in swagger-ui.css swagger-ui-bundle.js swagger-ui-standalone-preset.js; do
+    echo "⬇️  swagger-ui-dist@${SWAGGER_UI_VERSION}/${file}"
+    curl -fsSL "https://unpkg.com/swagger-ui-dist@${SWAGGER_UI_VERSION}/${file}" \
+        -o "$ASSETS_DIR/swagger-ui/${file}"
+done
+
+echo "⬇️  redoc@${REDOC_VERSION}/bundles/redoc.standalone.js"
+curl -fsSL "https://cdn.redoc.ly/redoc/v${REDOC_VERSION}/bundles/redoc.standalone.js" \
+    -o "$ASSETS_DIR/redoc/redoc.standalone.js"
+
+echo "✅ Documentation assets vendored (Swagger UI ${SWAGGER_UI_VERSION}, ReDoc ${REDOC_VERSION})"